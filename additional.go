@@ -0,0 +1,62 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// unknownProperties returns the properties of the JSON object in data
+// that have no field in struct type t, or nil if there are none.
+func unknownProperties(data []byte, t reflect.Type) (map[string]json.RawMessage, error) {
+	var props map[string]json.RawMessage
+	if err := json.Unmarshal(data, &props); err != nil {
+		return nil, err
+	}
+
+	known := jsonProperties(t)
+	var unknown map[string]json.RawMessage
+	for k, v := range props {
+		if _, ok := known[k]; !ok {
+			setRaw(&unknown, k, v)
+		}
+	}
+	return unknown, nil
+}
+
+// marshalWithAdditional encodes v, which must encode as a JSON object,
+// and adds the properties of each of extra to it. Properties of v take
+// precedence.
+func marshalWithAdditional(v interface{}, extra ...map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	n := 0
+	for _, m := range extra {
+		n += len(m)
+	}
+	if n == 0 {
+		return data, nil
+	}
+
+	var props map[string]json.RawMessage
+	if err := json.Unmarshal(data, &props); err != nil {
+		return nil, err
+	}
+	for _, m := range extra {
+		for k, v := range m {
+			if _, ok := props[k]; !ok {
+				props[k] = v
+			}
+		}
+	}
+	return json.Marshal(props)
+}
+
+// setRaw sets the property k of *m to v, creating the map if needed.
+func setRaw(m *map[string]json.RawMessage, k string, v json.RawMessage) {
+	if *m == nil {
+		*m = make(map[string]json.RawMessage)
+	}
+	(*m)[k] = v
+}
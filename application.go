@@ -0,0 +1,618 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/crosse/msgraph/edm"
+	"golang.org/x/net/context"
+)
+
+var applicationResource = RegisterResource(Resource{
+	Name:       "Application",
+	APIVersion: APIVersionV1,
+	Resource:   "applications",
+	Routes: map[string]Route{
+		"item":    "applications/{id}",
+		"byAppId": "applications(appId='{appId}')",
+
+		"addPassword":    "applications/{id}/addPassword",
+		"removePassword": "applications/{id}/removePassword",
+		"addKey":         "applications/{id}/addKey",
+		"removeKey":      "applications/{id}/removeKey",
+	},
+})
+
+var servicePrincipalResource = RegisterResource(Resource{
+	Name:       "ServicePrincipal",
+	APIVersion: APIVersionV1,
+	Resource:   "servicePrincipals",
+	Routes: map[string]Route{
+		"item":    "servicePrincipals/{id}",
+		"byAppId": "servicePrincipals(appId='{appId}')",
+
+		"addPassword":    "servicePrincipals/{id}/addPassword",
+		"removePassword": "servicePrincipals/{id}/removePassword",
+		"addKey":         "servicePrincipals/{id}/addKey",
+		"removeKey":      "servicePrincipals/{id}/removeKey",
+
+		"appRoleAssignments":     "servicePrincipals/{id}/appRoleAssignments",
+		"appRoleAssignedTo":      "servicePrincipals/{id}/appRoleAssignedTo",
+		"appRoleAssignedToItem":  "servicePrincipals/{id}/appRoleAssignedTo/{assignmentId}",
+		"oauth2PermissionGrants": "servicePrincipals/{id}/oauth2PermissionGrants",
+	},
+})
+
+// Values of Application.SignInAudience.
+const (
+	SignInAudienceMyOrg                    = "AzureADMyOrg"
+	SignInAudienceMultipleOrgs             = "AzureADMultipleOrgs"
+	SignInAudienceMultipleOrgsAndPersonal  = "AzureADandPersonalMicrosoftAccount"
+	SignInAudiencePersonalMicrosoftAccount = "PersonalMicrosoftAccount"
+)
+
+// AppRole is a role that an application declares and that can be
+// assigned to users, groups or other applications.
+type AppRole struct {
+	// The kinds of principals the role can be assigned to: "User"
+	// for users and groups, "Application" for applications, or both.
+	AllowedMemberTypes []string `json:"allowedMemberTypes,omitempty"`
+
+	// The description of the role, shown when it is assigned.
+	Description string `json:"description,omitempty"`
+
+	// The display name of the role.
+	DisplayName string `json:"displayName,omitempty"`
+
+	// The unique identifier of the role.
+	ID string `json:"id,omitempty"`
+
+	// Whether the role is enabled. Roles must be disabled before they
+	// are removed.
+	IsEnabled *bool `json:"isEnabled,omitempty"`
+
+	// Where the role was defined, "Application" or "ServicePrincipal".
+	// Read-only.
+	Origin string `json:"origin,omitempty"`
+
+	// The value of the role, included in the roles claim of tokens.
+	Value string `json:"value,omitempty"`
+}
+
+// PermissionScope is a delegated permission that an application
+// exposes, such as "User.Read".
+type PermissionScope struct {
+	// The description of the permission shown to administrators.
+	AdminConsentDescription string `json:"adminConsentDescription,omitempty"`
+
+	// The title of the permission shown to administrators.
+	AdminConsentDisplayName string `json:"adminConsentDisplayName,omitempty"`
+
+	// The unique identifier of the permission.
+	ID string `json:"id,omitempty"`
+
+	// Whether the permission is enabled.
+	IsEnabled *bool `json:"isEnabled,omitempty"`
+
+	// "User" if users can consent to the permission, "Admin" if only
+	// administrators can.
+	Type string `json:"type,omitempty"`
+
+	// The description of the permission shown to users.
+	UserConsentDescription string `json:"userConsentDescription,omitempty"`
+
+	// The title of the permission shown to users.
+	UserConsentDisplayName string `json:"userConsentDisplayName,omitempty"`
+
+	// The value of the permission, included in the scp claim of
+	// tokens.
+	Value string `json:"value,omitempty"`
+}
+
+// ResourceAccess is an app role or delegated permission of a resource
+// that an application requires.
+type ResourceAccess struct {
+	// The ID of an app role or permission scope of the resource.
+	ID string `json:"id,omitempty"`
+
+	// "Role" for an app role, "Scope" for a delegated permission.
+	Type string `json:"type,omitempty"`
+}
+
+// RequiredResourceAccess lists the permissions an application requires
+// on a single resource, such as Microsoft Graph.
+type RequiredResourceAccess struct {
+	// The application ID of the resource.
+	ResourceAppID string `json:"resourceAppId,omitempty"`
+
+	// The app roles and delegated permissions required.
+	ResourceAccess []ResourceAccess `json:"resourceAccess,omitempty"`
+}
+
+// Application is an application registration: the global definition of
+// an application, from which a service principal is created in each
+// tenant that uses it.
+type Application struct {
+	// The application ID, also known as the client ID, which
+	// identifies the application in all tenants. Read-only.
+	AppID string `json:"appId,omitempty"`
+
+	// The roles the application declares.
+	AppRoles []AppRole `json:"appRoles,omitempty"`
+
+	// The date and time the application was registered, in UTC.
+	// Read-only.
+	CreatedDateTime *edm.DateTimeOffset `json:"createdDateTime,omitempty"`
+
+	// The date and time at which the application was deleted, in UTC.
+	// Read-only.
+	DeletedDateTime *edm.DateTimeOffset `json:"deletedDateTime,omitempty"`
+
+	// An optional description of the application.
+	Description string `json:"description,omitempty"`
+
+	// The display name of the application. This property is required
+	// when an application is registered.
+	DisplayName string `json:"displayName,omitempty"`
+
+	// The unique identifier of the application object. Read-only.
+	ID string `json:"id,omitempty"`
+
+	// The URIs that identify the application as a resource, such as
+	// "api://<appId>".
+	IdentifierURIs []string `json:"identifierUris,omitempty"`
+
+	// The certificates of the application.
+	KeyCredentials []KeyCredential `json:"keyCredentials,omitempty"`
+
+	// Notes about the management of the application.
+	Notes string `json:"notes,omitempty"`
+
+	// The client secrets of the application. Their values are only
+	// returned when they are added with AddApplicationPassword.
+	PasswordCredentials []PasswordCredential `json:"passwordCredentials,omitempty"`
+
+	// The verified publisher domain of the application. Read-only.
+	PublisherDomain string `json:"publisherDomain,omitempty"`
+
+	// The permissions the application requires on other resources.
+	RequiredResourceAccess []RequiredResourceAccess `json:"requiredResourceAccess,omitempty"`
+
+	// The accounts that can sign in to the application, one of the
+	// SignInAudience constants.
+	SignInAudience string `json:"signInAudience,omitempty"`
+
+	// Custom strings that categorize the application.
+	Tags []string `json:"tags,omitempty"`
+
+	// AdditionalData holds the properties and annotations returned by
+	// Graph that Application has no field for, keyed by their names.
+	AdditionalData map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler. Additional data is encoded
+// alongside the built-in properties.
+func (a Application) MarshalJSON() ([]byte, error) {
+	type application Application
+	return marshalWithAdditional(application(a), a.AdditionalData)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (a *Application) UnmarshalJSON(data []byte) error {
+	type application Application
+	if err := json.Unmarshal(data, (*application)(a)); err != nil {
+		return err
+	}
+	unknown, err := unknownProperties(data, reflect.TypeOf(*a))
+	a.AdditionalData = unknown
+	return err
+}
+
+// ObjectID implements DirectoryObject.
+func (a *Application) ObjectID() string { return a.ID }
+
+// ObjectType implements DirectoryObject.
+func (a *Application) ObjectType() string { return ODataTypeApplication }
+
+// validateCreate checks the properties needed to register an
+// application.
+func (a *Application) validateCreate() error {
+	e := &ValidationError{}
+
+	if strings.TrimSpace(a.DisplayName) == "" {
+		e.add("displayName", "is required")
+	}
+	switch a.SignInAudience {
+	case "", SignInAudienceMyOrg, SignInAudienceMultipleOrgs,
+		SignInAudienceMultipleOrgsAndPersonal, SignInAudiencePersonalMicrosoftAccount:
+	default:
+		e.add("signInAudience", "must be one of %v, %v, %v or %v",
+			SignInAudienceMyOrg, SignInAudienceMultipleOrgs,
+			SignInAudienceMultipleOrgsAndPersonal, SignInAudiencePersonalMicrosoftAccount)
+	}
+	for _, role := range a.AppRoles {
+		if role.ID == "" || role.Value == "" {
+			e.add("appRoles", "must each have an id and a value")
+			break
+		}
+	}
+
+	return e.err()
+}
+
+// ServicePrincipal is the instance of an application in a tenant. It is
+// what users sign in to and what app roles and permissions are granted
+// to.
+type ServicePrincipal struct {
+	// Whether the service principal can sign in.
+	AccountEnabled *bool `json:"accountEnabled,omitempty"`
+
+	// The display name of the application. Read-only.
+	AppDisplayName string `json:"appDisplayName,omitempty"`
+
+	// The application ID of the application the service principal
+	// belongs to. This property is required when a service principal
+	// is created.
+	AppID string `json:"appId,omitempty"`
+
+	// The ID of the tenant the application is registered in.
+	// Read-only.
+	AppOwnerOrganizationID string `json:"appOwnerOrganizationId,omitempty"`
+
+	// Whether users and other applications must be assigned an app
+	// role before they can sign in to the application.
+	AppRoleAssignmentRequired *bool `json:"appRoleAssignmentRequired,omitempty"`
+
+	// The roles the application declares. Read-only.
+	AppRoles []AppRole `json:"appRoles,omitempty"`
+
+	// The date and time at which the service principal was deleted,
+	// in UTC. Read-only.
+	DeletedDateTime *edm.DateTimeOffset `json:"deletedDateTime,omitempty"`
+
+	// An optional description of the service principal.
+	Description string `json:"description,omitempty"`
+
+	// The display name of the service principal.
+	DisplayName string `json:"displayName,omitempty"`
+
+	// The unique identifier of the service principal. Read-only.
+	ID string `json:"id,omitempty"`
+
+	// The certificates of the service principal.
+	KeyCredentials []KeyCredential `json:"keyCredentials,omitempty"`
+
+	// Notes about the service principal.
+	Notes string `json:"notes,omitempty"`
+
+	// The delegated permissions the application exposes. Read-only.
+	OAuth2PermissionScopes []PermissionScope `json:"oauth2PermissionScopes,omitempty"`
+
+	// The client secrets of the service principal.
+	PasswordCredentials []PasswordCredential `json:"passwordCredentials,omitempty"`
+
+	// The single sign-on mode of the service principal, such as
+	// "saml" or "password".
+	PreferredSingleSignOnMode string `json:"preferredSingleSignOnMode,omitempty"`
+
+	// The names the service principal is known by, including its
+	// application ID and identifier URIs.
+	ServicePrincipalNames []string `json:"servicePrincipalNames,omitempty"`
+
+	// "Application" for the service principal of an application,
+	// "ManagedIdentity" for a managed identity. Read-only.
+	ServicePrincipalType string `json:"servicePrincipalType,omitempty"`
+
+	// The accounts that can sign in to the application. Read-only.
+	SignInAudience string `json:"signInAudience,omitempty"`
+
+	// Custom strings that categorize the service principal.
+	Tags []string `json:"tags,omitempty"`
+
+	// AdditionalData holds the properties and annotations returned by
+	// Graph that ServicePrincipal has no field for, keyed by their
+	// names.
+	AdditionalData map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler. Additional data is encoded
+// alongside the built-in properties.
+func (sp ServicePrincipal) MarshalJSON() ([]byte, error) {
+	type servicePrincipal ServicePrincipal
+	return marshalWithAdditional(servicePrincipal(sp), sp.AdditionalData)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (sp *ServicePrincipal) UnmarshalJSON(data []byte) error {
+	type servicePrincipal ServicePrincipal
+	if err := json.Unmarshal(data, (*servicePrincipal)(sp)); err != nil {
+		return err
+	}
+	unknown, err := unknownProperties(data, reflect.TypeOf(*sp))
+	sp.AdditionalData = unknown
+	return err
+}
+
+// ObjectID implements DirectoryObject.
+func (sp *ServicePrincipal) ObjectID() string { return sp.ID }
+
+// ObjectType implements DirectoryObject.
+func (sp *ServicePrincipal) ObjectType() string { return ODataTypeServicePrincipal }
+
+// AppRole returns the app role with the given ID or value, if the
+// service principal has one.
+func (sp *ServicePrincipal) AppRole(idOrValue string) (AppRole, bool) {
+	for _, role := range sp.AppRoles {
+		if strings.EqualFold(role.ID, idOrValue) || role.Value == idOrValue {
+			return role, true
+		}
+	}
+	return AppRole{}, false
+}
+
+// GetApplication retrieves the properties of the application with the
+// given object ID. properties may be empty to retrieve the default
+// properties.
+func (api *GraphAPI) GetApplication(ctx context.Context, id string, properties []string) (app Application, err error) {
+	return app, api.getObject(ctx, "GetApplication", applicationResource, "item", id, properties, &app)
+}
+
+// GetApplicationByAppID is like GetApplication, but finds the
+// application by its application (client) ID.
+func (api *GraphAPI) GetApplicationByAppID(ctx context.Context, appID string, properties []string) (app Application, err error) {
+	return app, api.getObject(ctx, "GetApplicationByAppID", applicationResource, "byAppId", appID, properties, &app)
+}
+
+// GetServicePrincipal retrieves the properties of the service principal
+// with the given object ID. properties may be empty to retrieve the
+// default properties.
+func (api *GraphAPI) GetServicePrincipal(ctx context.Context, id string, properties []string) (sp ServicePrincipal, err error) {
+	return sp, api.getObject(ctx, "GetServicePrincipal", servicePrincipalResource, "item", id, properties, &sp)
+}
+
+// GetServicePrincipalByAppID is like GetServicePrincipal, but finds the
+// service principal by the application (client) ID of its application.
+func (api *GraphAPI) GetServicePrincipalByAppID(ctx context.Context, appID string, properties []string) (sp ServicePrincipal, err error) {
+	return sp, api.getObject(ctx, "GetServicePrincipalByAppID", servicePrincipalResource, "byAppId", appID, properties, &sp)
+}
+
+// getObject retrieves a single object of resource r through route.
+func (api *GraphAPI) getObject(ctx context.Context, operation string, r Resource, route, id string, properties []string, out interface{}) (err error) {
+	ctx, span := api.startOperation(ctx, operation, r)
+	defer func() { endOperation(span, err) }()
+
+	log.WithFields(log.Fields{
+		"resource": r.Name,
+		"id":       id,
+	}).Info("Getting object from Graph API")
+
+	endpoint, err := api.Endpoint(ctx, r, route, id)
+	if err != nil {
+		return
+	}
+	ctx = (&Query{Select: properties}).apply(ctx, endpoint)
+
+	return api.send(ctx, r, "GET", endpoint, nil, out)
+}
+
+// ApplicationIterator iterates over the applications returned by
+// ListApplications.
+type ApplicationIterator struct {
+	c   *Collection
+	app Application
+}
+
+// Next advances the iterator to the next application, which can then
+// be read with Application. It returns false when there are no more
+// applications or an error occurred; Err tells the two apart.
+func (it *ApplicationIterator) Next() bool {
+	if !it.c.Next() {
+		return false
+	}
+	it.app = Application{}
+	if err := it.c.Decode(&it.app); err != nil {
+		it.c.Close()
+		return false
+	}
+	return true
+}
+
+// Application returns the current application.
+func (it *ApplicationIterator) Application() Application {
+	return it.app
+}
+
+// Count returns the total number of matching applications, if
+// Query.Count was set.
+func (it *ApplicationIterator) Count() (int64, bool) {
+	return it.c.Count()
+}
+
+// Err returns the first error encountered while iterating.
+func (it *ApplicationIterator) Err() error {
+	return it.c.Err()
+}
+
+// Close stops the iteration early.
+func (it *ApplicationIterator) Close() error {
+	return it.c.Close()
+}
+
+// ListApplications returns an iterator over the applications registered
+// in the tenant that match q, which may be nil. Pages are requested as
+// the iterator advances.
+func (api *GraphAPI) ListApplications(ctx context.Context, q *Query) *ApplicationIterator {
+	log.WithFields(log.Fields{
+		"filter": q.values().Get("$filter"),
+	}).Info("Listing applications from Graph API")
+
+	endpoint, err := api.Endpoint(ctx, applicationResource, "")
+	if err != nil {
+		return &ApplicationIterator{c: errCollection(err)}
+	}
+	ctx = q.apply(ctx, endpoint)
+
+	return &ApplicationIterator{c: api.newCollection(ctx, "ListApplications", applicationResource, endpoint.String())}
+}
+
+// ServicePrincipalIterator iterates over the service principals
+// returned by ListServicePrincipals.
+type ServicePrincipalIterator struct {
+	c  *Collection
+	sp ServicePrincipal
+}
+
+// Next advances the iterator to the next service principal, which can
+// then be read with ServicePrincipal. It returns false when there are
+// no more service principals or an error occurred; Err tells the two
+// apart.
+func (it *ServicePrincipalIterator) Next() bool {
+	if !it.c.Next() {
+		return false
+	}
+	it.sp = ServicePrincipal{}
+	if err := it.c.Decode(&it.sp); err != nil {
+		it.c.Close()
+		return false
+	}
+	return true
+}
+
+// ServicePrincipal returns the current service principal.
+func (it *ServicePrincipalIterator) ServicePrincipal() ServicePrincipal {
+	return it.sp
+}
+
+// Count returns the total number of matching service principals, if
+// Query.Count was set.
+func (it *ServicePrincipalIterator) Count() (int64, bool) {
+	return it.c.Count()
+}
+
+// Err returns the first error encountered while iterating.
+func (it *ServicePrincipalIterator) Err() error {
+	return it.c.Err()
+}
+
+// Close stops the iteration early.
+func (it *ServicePrincipalIterator) Close() error {
+	return it.c.Close()
+}
+
+// ListServicePrincipals returns an iterator over the service principals
+// in the tenant that match q, which may be nil. Pages are requested as
+// the iterator advances.
+func (api *GraphAPI) ListServicePrincipals(ctx context.Context, q *Query) *ServicePrincipalIterator {
+	log.WithFields(log.Fields{
+		"filter": q.values().Get("$filter"),
+	}).Info("Listing service principals from Graph API")
+
+	endpoint, err := api.Endpoint(ctx, servicePrincipalResource, "")
+	if err != nil {
+		return &ServicePrincipalIterator{c: errCollection(err)}
+	}
+	ctx = q.apply(ctx, endpoint)
+
+	return &ServicePrincipalIterator{c: api.newCollection(ctx, "ListServicePrincipals", servicePrincipalResource, endpoint.String())}
+}
+
+// CreateApplication registers an application and returns it. Graph
+// does not create its service principal; use CreateServicePrincipal
+// for that.
+func (api *GraphAPI) CreateApplication(ctx context.Context, app Application) (created Application, err error) {
+	ctx, span := api.startOperation(ctx, "CreateApplication", applicationResource)
+	defer func() { endOperation(span, err) }()
+
+	if err = app.validateCreate(); err != nil {
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"application": app.DisplayName,
+	}).Info("Creating application in Graph API")
+
+	endpoint, err := api.Endpoint(ctx, applicationResource, "")
+	if err != nil {
+		return
+	}
+	body, err := withoutNulls(app)
+	if err != nil {
+		return
+	}
+	err = api.send(ctx, applicationResource, "POST", endpoint, body, &created)
+	return
+}
+
+// CreateServicePrincipal creates the service principal of the
+// application with sp.AppID in the tenant and returns it.
+func (api *GraphAPI) CreateServicePrincipal(ctx context.Context, sp ServicePrincipal) (created ServicePrincipal, err error) {
+	ctx, span := api.startOperation(ctx, "CreateServicePrincipal", servicePrincipalResource)
+	defer func() { endOperation(span, err) }()
+
+	if sp.AppID == "" {
+		return created, &ValidationError{[]*FieldError{{"appId", "is required"}}}
+	}
+
+	log.WithFields(log.Fields{
+		"appId": sp.AppID,
+	}).Info("Creating service principal in Graph API")
+
+	endpoint, err := api.Endpoint(ctx, servicePrincipalResource, "")
+	if err != nil {
+		return
+	}
+	body, err := withoutNulls(sp)
+	if err != nil {
+		return
+	}
+	err = api.send(ctx, servicePrincipalResource, "POST", endpoint, body, &created)
+	return
+}
+
+// NewApplicationPatch returns an empty Patch for an application.
+func NewApplicationPatch() *Patch {
+	return NewPatch(Application{})
+}
+
+// NewServicePrincipalPatch returns an empty Patch for a service
+// principal.
+func NewServicePrincipalPatch() *Patch {
+	return NewPatch(ServicePrincipal{})
+}
+
+// UpdateApplication applies patch to the application with the given
+// object ID. Only the properties in the patch are sent.
+func (api *GraphAPI) UpdateApplication(ctx context.Context, id string, patch *Patch) error {
+	return api.updateObject(ctx, "UpdateApplication", applicationResource, id, patch)
+}
+
+// UpdateServicePrincipal applies patch to the service principal with
+// the given object ID. Only the properties in the patch are sent.
+func (api *GraphAPI) UpdateServicePrincipal(ctx context.Context, id string, patch *Patch) error {
+	return api.updateObject(ctx, "UpdateServicePrincipal", servicePrincipalResource, id, patch)
+}
+
+// updateObject applies patch to the object of resource r with the given
+// ID.
+func (api *GraphAPI) updateObject(ctx context.Context, operation string, r Resource, id string, patch *Patch) (err error) {
+	ctx, span := api.startOperation(ctx, operation, r)
+	defer func() { endOperation(span, err) }()
+
+	if patch.Len() == 0 {
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"resource":   r.Name,
+		"id":         id,
+		"properties": patch.Properties(),
+	}).Info("Updating object in Graph API")
+
+	endpoint, err := api.Endpoint(ctx, r, "item", id)
+	if err != nil {
+		return
+	}
+	return api.send(ctx, r, "PATCH", endpoint, patch, nil)
+}
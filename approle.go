@@ -0,0 +1,258 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/crosse/msgraph/edm"
+	"golang.org/x/net/context"
+)
+
+// DefaultAppRoleID is the app role ID of an assignment that grants
+// access to an application which declares no app roles.
+const DefaultAppRoleID = "00000000-0000-0000-0000-000000000000"
+
+// AppRoleAssignment records that a user, group or service principal
+// (the principal) holds an app role of a resource service principal.
+type AppRoleAssignment struct {
+	// The ID of the app role held. DefaultAppRoleID means default
+	// access to a resource without app roles.
+	AppRoleID string `json:"appRoleId,omitempty"`
+
+	// The date and time the assignment was created, in UTC.
+	// Read-only.
+	CreatedDateTime *edm.DateTimeOffset `json:"createdDateTime,omitempty"`
+
+	// The date and time at which the assignment was deleted, in UTC.
+	// Read-only.
+	DeletedDateTime *edm.DateTimeOffset `json:"deletedDateTime,omitempty"`
+
+	// The unique identifier of the assignment. Read-only.
+	ID string `json:"id,omitempty"`
+
+	// The display name of the principal. Read-only.
+	PrincipalDisplayName string `json:"principalDisplayName,omitempty"`
+
+	// The object ID of the user, group or service principal that
+	// holds the role.
+	PrincipalID string `json:"principalId,omitempty"`
+
+	// "User", "Group" or "ServicePrincipal". Read-only.
+	PrincipalType string `json:"principalType,omitempty"`
+
+	// The display name of the resource. Read-only.
+	ResourceDisplayName string `json:"resourceDisplayName,omitempty"`
+
+	// The object ID of the service principal that declares the role.
+	ResourceID string `json:"resourceId,omitempty"`
+
+	// AdditionalData holds the properties and annotations returned by
+	// Graph that AppRoleAssignment has no field for, keyed by their
+	// names.
+	AdditionalData map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler. Additional data is encoded
+// alongside the built-in properties.
+func (a AppRoleAssignment) MarshalJSON() ([]byte, error) {
+	type appRoleAssignment AppRoleAssignment
+	return marshalWithAdditional(appRoleAssignment(a), a.AdditionalData)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (a *AppRoleAssignment) UnmarshalJSON(data []byte) error {
+	type appRoleAssignment AppRoleAssignment
+	if err := json.Unmarshal(data, (*appRoleAssignment)(a)); err != nil {
+		return err
+	}
+	unknown, err := unknownProperties(data, reflect.TypeOf(*a))
+	a.AdditionalData = unknown
+	return err
+}
+
+// AppRoleAssignmentIterator iterates over app role assignments.
+type AppRoleAssignmentIterator struct {
+	c          *Collection
+	assignment AppRoleAssignment
+}
+
+// Next advances the iterator to the next assignment, which can then be
+// read with Assignment. It returns false when there are no more
+// assignments or an error occurred; Err tells the two apart.
+func (it *AppRoleAssignmentIterator) Next() bool {
+	if !it.c.Next() {
+		return false
+	}
+	it.assignment = AppRoleAssignment{}
+	if err := it.c.Decode(&it.assignment); err != nil {
+		it.c.Close()
+		return false
+	}
+	return true
+}
+
+// Assignment returns the current assignment.
+func (it *AppRoleAssignmentIterator) Assignment() AppRoleAssignment {
+	return it.assignment
+}
+
+// Count returns the total number of matching assignments, if
+// Query.Count was set.
+func (it *AppRoleAssignmentIterator) Count() (int64, bool) {
+	return it.c.Count()
+}
+
+// Err returns the first error encountered while iterating.
+func (it *AppRoleAssignmentIterator) Err() error {
+	return it.c.Err()
+}
+
+// Close stops the iteration early.
+func (it *AppRoleAssignmentIterator) Close() error {
+	return it.c.Close()
+}
+
+// ListServicePrincipalAppRoleAssignments returns an iterator over the
+// app roles the service principal with the given ID holds on other
+// resources, such as the application permissions it has been granted on
+// Microsoft Graph. q may be nil.
+func (api *GraphAPI) ListServicePrincipalAppRoleAssignments(ctx context.Context, id string, q *Query) *AppRoleAssignmentIterator {
+	return api.listAppRoleAssignments(ctx, "ListServicePrincipalAppRoleAssignments", servicePrincipalResource, "appRoleAssignments", id, q)
+}
+
+// ListAppRoleAssignedTo returns an iterator over the assignments of the
+// app roles of the resource service principal with the given ID: the
+// users, groups and service principals that hold them. q may be nil.
+func (api *GraphAPI) ListAppRoleAssignedTo(ctx context.Context, id string, q *Query) *AppRoleAssignmentIterator {
+	return api.listAppRoleAssignments(ctx, "ListAppRoleAssignedTo", servicePrincipalResource, "appRoleAssignedTo", id, q)
+}
+
+// ListUserAppRoleAssignments returns an iterator over the app roles
+// assigned directly to the user with the given ID. q may be nil.
+func (api *GraphAPI) ListUserAppRoleAssignments(ctx context.Context, id string, q *Query) *AppRoleAssignmentIterator {
+	return api.listAppRoleAssignments(ctx, "ListUserAppRoleAssignments", userResource, "appRoleAssignments", id, q)
+}
+
+// ListGroupAppRoleAssignments returns an iterator over the app roles
+// assigned to the group with the given ID. q may be nil.
+func (api *GraphAPI) ListGroupAppRoleAssignments(ctx context.Context, id string, q *Query) *AppRoleAssignmentIterator {
+	return api.listAppRoleAssignments(ctx, "ListGroupAppRoleAssignments", groupResource, "appRoleAssignments", id, q)
+}
+
+func (api *GraphAPI) listAppRoleAssignments(ctx context.Context, operation string, r Resource, route, id string, q *Query) *AppRoleAssignmentIterator {
+	endpoint, err := api.Endpoint(ctx, r, route, id)
+	if err != nil {
+		return &AppRoleAssignmentIterator{c: errCollection(err)}
+	}
+	ctx = q.apply(ctx, endpoint)
+
+	return &AppRoleAssignmentIterator{c: api.newCollection(ctx, operation, r, endpoint.String())}
+}
+
+// AssignAppRole assigns the app role with ID appRoleID of the resource
+// service principal with ID resourceID to the user, group or service
+// principal with ID principalID, and returns the assignment. Assigning
+// an app role of Microsoft Graph to a service principal grants it an
+// application permission.
+func (api *GraphAPI) AssignAppRole(ctx context.Context, resourceID, appRoleID, principalID string) (created AppRoleAssignment, err error) {
+	ctx, span := api.startOperation(ctx, "AssignAppRole", servicePrincipalResource)
+	defer func() { endOperation(span, err) }()
+
+	log.WithFields(log.Fields{
+		"resource":  resourceID,
+		"appRole":   appRoleID,
+		"principal": principalID,
+	}).Info("Assigning app role in Graph API")
+
+	endpoint, err := api.Endpoint(ctx, servicePrincipalResource, "appRoleAssignedTo", resourceID)
+	if err != nil {
+		return
+	}
+
+	body := AppRoleAssignment{
+		AppRoleID:   appRoleID,
+		PrincipalID: principalID,
+		ResourceID:  resourceID,
+	}
+	data, err := withoutNulls(body)
+	if err != nil {
+		return
+	}
+	err = api.send(ctx, servicePrincipalResource, "POST", endpoint, data, &created)
+	return
+}
+
+// RemoveAppRoleAssignment removes the assignment with the given ID of an
+// app role of the resource service principal with ID resourceID.
+func (api *GraphAPI) RemoveAppRoleAssignment(ctx context.Context, resourceID, assignmentID string) (err error) {
+	ctx, span := api.startOperation(ctx, "RemoveAppRoleAssignment", servicePrincipalResource)
+	defer func() { endOperation(span, err) }()
+
+	log.WithFields(log.Fields{
+		"resource":   resourceID,
+		"assignment": assignmentID,
+	}).Info("Removing app role assignment in Graph API")
+
+	endpoint, err := api.Endpoint(ctx, servicePrincipalResource, "appRoleAssignedToItem", resourceID, assignmentID)
+	if err != nil {
+		return
+	}
+	return api.send(ctx, servicePrincipalResource, "DELETE", endpoint, nil, nil)
+}
+
+// resourceProperties are the service principal properties an
+// AppRoleResolver needs.
+var resourceProperties = []string{"id", "appId", "displayName", "appRoles", "oauth2PermissionScopes"}
+
+// AppRoleResolver resolves the app role IDs of assignments to the names
+// of the roles, looking them up in the app roles of the resource
+// service principals. Resource service principals are retrieved once
+// and cached. An AppRoleResolver is not safe for concurrent use.
+type AppRoleResolver struct {
+	api       *GraphAPI
+	resources map[string]*ServicePrincipal
+}
+
+// NewAppRoleResolver returns an AppRoleResolver that retrieves resource
+// service principals through api.
+func (api *GraphAPI) NewAppRoleResolver() *AppRoleResolver {
+	return &AppRoleResolver{api: api, resources: make(map[string]*ServicePrincipal)}
+}
+
+// Resource returns the service principal with the given object ID with
+// its app roles and delegated permission scopes.
+func (r *AppRoleResolver) Resource(ctx context.Context, id string) (*ServicePrincipal, error) {
+	if sp, ok := r.resources[strings.ToLower(id)]; ok {
+		return sp, nil
+	}
+	sp, err := r.api.GetServicePrincipal(ctx, id, resourceProperties)
+	if err != nil {
+		return nil, err
+	}
+	r.resources[strings.ToLower(id)] = &sp
+	return &sp, nil
+}
+
+// RoleName returns the name of the app role held by assignment a: the
+// role's value, such as "User.Read.All", or its display name if it has
+// no value. Default access is named "Default access", and roles the
+// resource no longer declares are named by their IDs.
+func (r *AppRoleResolver) RoleName(ctx context.Context, a AppRoleAssignment) (string, error) {
+	if a.AppRoleID == DefaultAppRoleID {
+		return "Default access", nil
+	}
+	sp, err := r.Resource(ctx, a.ResourceID)
+	if err != nil {
+		return "", err
+	}
+	role, ok := sp.AppRole(a.AppRoleID)
+	switch {
+	case !ok:
+		return a.AppRoleID, nil
+	case role.Value != "":
+		return role.Value, nil
+	}
+	return role.DisplayName, nil
+}
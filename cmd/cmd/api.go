@@ -0,0 +1,192 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/crosse/msgraph"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+var (
+	apiFields   []string
+	apiHeaders  []string
+	apiInput    string
+	apiBeta     bool
+	apiPaginate bool
+)
+
+// apiCmd represents the api command
+var apiCmd = &cobra.Command{
+	Use:   "api [METHOD] <path>",
+	Short: "Make an authenticated request to the Graph API",
+	Long: `Make an authenticated request to any Graph API path and print the
+JSON response. The path is relative to the API version root, for
+example "/me/memberOf" or "/groups?$top=5".
+
+The method defaults to GET, or to POST when fields or an input file
+are given. Fields are sent as query parameters on GET requests and as
+a JSON object in the body otherwise.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := callAPI(args); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(apiCmd)
+
+	apiCmd.Flags().StringArrayVarP(&apiFields, "field", "f", nil, "Add a key=value parameter to the request")
+	apiCmd.Flags().StringArrayVarP(&apiHeaders, "header", "H", nil, "Add a \"Key: Value\" header to the request")
+	apiCmd.Flags().StringVar(&apiInput, "input", "", "The file to use as the request body (\"-\" for stdin)")
+	apiCmd.Flags().BoolVar(&apiBeta, "beta", false, "Use the beta API instead of v1.0")
+	apiCmd.Flags().BoolVar(&apiPaginate, "paginate", false, "Follow @odata.nextLink to fetch all pages of a GET request")
+}
+
+func callAPI(args []string) error {
+	var method, path string
+	switch len(args) {
+	case 1:
+		path = args[0]
+	case 2:
+		method, path = strings.ToUpper(args[0]), args[1]
+	default:
+		return fmt.Errorf("expected [METHOD] <path>")
+	}
+	if method == "" {
+		method = "GET"
+		if len(apiFields) > 0 || apiInput != "" {
+			method = "POST"
+		}
+	}
+
+	version := msgraph.APIVersionV1
+	if apiBeta {
+		version = msgraph.APIVersionBeta
+	}
+
+	ctx := context.Background()
+	for _, h := range apiHeaders {
+		kv := strings.SplitN(h, ":", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("invalid header %q", h)
+		}
+		ctx = msgraph.WithHeader(ctx, strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1]))
+	}
+
+	fields := make(map[string]string)
+	for _, f := range apiFields {
+		kv := strings.SplitN(f, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("invalid field %q", f)
+		}
+		fields[kv[0]] = kv[1]
+	}
+
+	var body []byte
+	var err error
+	switch {
+	case apiInput == "-":
+		body, err = ioutil.ReadAll(os.Stdin)
+	case apiInput != "":
+		body, err = ioutil.ReadFile(apiInput)
+	case len(fields) > 0 && method != "GET":
+		body, err = json.Marshal(fields)
+	}
+	if err != nil {
+		return err
+	}
+	if len(fields) > 0 && method == "GET" {
+		path = addQuery(path, fields)
+	}
+
+	api := setupAPI()
+	for path != "" {
+		var r io.Reader
+		if body != nil {
+			r = bytes.NewReader(body)
+		}
+		resp, err := api.Request(ctx, method, version, path, r)
+		if err != nil {
+			return err
+		}
+		data, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		printJSON(data)
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("%s", resp.Status)
+		}
+
+		path = ""
+		if apiPaginate && method == "GET" {
+			var page struct {
+				NextLink string `json:"@odata.nextLink"`
+			}
+			if json.Unmarshal(data, &page) == nil {
+				path = page.NextLink
+			}
+		}
+	}
+	return nil
+}
+
+// addQuery adds params to the query string of path.
+func addQuery(path string, params map[string]string) string {
+	q := url.Values{}
+	for k, v := range params {
+		q.Set(k, v)
+	}
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	return path + sep + q.Encode()
+}
+
+// printJSON writes data to stdout, indenting it if it is JSON.
+func printJSON(data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		os.Stdout.Write(data)
+		fmt.Println()
+		return
+	}
+	buf.WriteTo(os.Stdout)
+	fmt.Println()
+}
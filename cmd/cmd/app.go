@@ -0,0 +1,48 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"github.com/crosse/msgraph"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+// appCmd represents the app command
+var appCmd = &cobra.Command{
+	Use:   "app",
+	Short: "Application management",
+	Long:  `List/Get applications, manage their credentials and report their permissions`,
+}
+
+func init() {
+	RootCmd.AddCommand(appCmd)
+}
+
+// findApplication returns the application with the given application
+// (client) ID or object ID.
+func findApplication(ctx context.Context, api *msgraph.GraphAPI, idOrAppID string, properties []string) (msgraph.Application, error) {
+	app, err := api.GetApplicationByAppID(ctx, idOrAppID, properties)
+	if msgraph.IsNotFound(err) {
+		return api.GetApplication(ctx, idOrAppID, properties)
+	}
+	return app, err
+}
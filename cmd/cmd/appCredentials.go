@@ -0,0 +1,256 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/crosse/msgraph"
+	"github.com/crosse/msgraph/edm"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/net/context"
+)
+
+var (
+	appCredentialsDays int
+	appCredentialsAll  bool
+
+	appRotateKeyID     string
+	appRotateName      string
+	appRotateOutput    string
+	appRotateLifetime  int
+	appRotateRemoveOld bool
+	appRotateYes       bool
+)
+
+// appCredentialsCmd represents the app credentials command
+var appCredentialsCmd = &cobra.Command{
+	Use:   "credentials [<app>]",
+	Short: "List application secrets and certificates nearing expiry",
+	Long: `List the client secrets and certificates of all applications and
+service principals that expire within --days, or with --all every one of
+them. With <app>, given by its application (client) ID or object ID, all
+credentials of that application are listed.
+
+The secret msgraph itself authenticates with is marked with "*".`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) > 1 {
+			fmt.Fprintln(os.Stderr, "Error: expected at most one <app>")
+			os.Exit(1)
+		}
+		if err := listCredentials(args); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+	},
+}
+
+// appCredentialsRotateCmd represents the app credentials rotate command
+var appCredentialsRotateCmd = &cobra.Command{
+	Use:   "rotate <app>",
+	Short: "Add a new client secret to an application",
+	Long: `Add a new client secret to an application and write its value to the
+--output file, which is created with mode 0600 and must not exist.
+
+The secret being replaced is given with --key-id. It is only removed with
+--remove-old, after the new secret has been written; otherwise remove it
+once every client uses the new secret. If it is the secret msgraph itself
+authenticates with, --remove-old asks for confirmation first, unless
+--yes is given.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			fmt.Fprintln(os.Stderr, "Error: expected <app>")
+			os.Exit(1)
+		}
+		if err := rotateSecret(args[0]); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	appCmd.AddCommand(appCredentialsCmd)
+	appCredentialsCmd.AddCommand(appCredentialsRotateCmd)
+
+	appCredentialsCmd.Flags().IntVar(&appCredentialsDays, "days", 30, "List credentials that expire within this many days")
+	appCredentialsCmd.Flags().BoolVar(&appCredentialsAll, "all", false, "List all credentials")
+
+	appCredentialsRotateCmd.Flags().StringVar(&appRotateKeyID, "key-id", "", "The key ID of the secret being replaced")
+	appCredentialsRotateCmd.Flags().StringVar(&appRotateName, "name", "", "The name of the new secret (default: the name of the replaced secret)")
+	appCredentialsRotateCmd.Flags().StringVarP(&appRotateOutput, "output", "o", "", "The file to write the new secret to")
+	appCredentialsRotateCmd.Flags().IntVar(&appRotateLifetime, "lifetime", 365, "The number of days the new secret is valid")
+	appCredentialsRotateCmd.Flags().BoolVar(&appRotateRemoveOld, "remove-old", false, "Remove the replaced secret")
+	appCredentialsRotateCmd.Flags().BoolVarP(&appRotateYes, "yes", "y", false, "Do not ask for confirmation before removing msgraph's own secret")
+}
+
+func listCredentials(args []string) error {
+	api := setupAPI()
+	ctx := context.Background()
+
+	var creds []msgraph.Credential
+	if len(args) == 1 {
+		app, err := findApplication(ctx, api, args[0], []string{"id", "appId", "displayName", "passwordCredentials", "keyCredentials"})
+		if err != nil {
+			return err
+		}
+		creds = app.Credentials()
+	} else {
+		var err error
+		if creds, err = api.ListCredentials(ctx); err != nil {
+			return err
+		}
+	}
+
+	// Failing to find our own secret, for example for lack of
+	// permission to read the application, only loses the marker.
+	current, _ := api.CurrentClientSecret(ctx)
+
+	now := time.Now()
+	deadline := now.AddDate(0, 0, appCredentialsDays)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "\tstatus\texpires\tkind\towner\tappId\tkeyId\tname")
+	for _, c := range creds {
+		if len(args) == 0 && !appCredentialsAll && !c.ExpiresBefore(deadline) {
+			continue
+		}
+
+		mark := ""
+		if current.KeyID != "" && c.KeyID == current.KeyID && strings.EqualFold(c.AppID, viper.GetString("clientID")) {
+			mark = "*"
+		}
+		status := "ok"
+		switch {
+		case c.ExpiresBefore(now):
+			status = "expired"
+		case c.ExpiresBefore(deadline):
+			status = fmt.Sprintf("%dd left", int(c.EndDateTime.Time.Sub(now).Hours()/24))
+		}
+		owner := c.OwnerName
+		if c.OwnerType == msgraph.ODataTypeServicePrincipal {
+			owner += " (service principal)"
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n", mark, status, c.EndDateTime, c.Kind, owner, c.AppID, c.KeyID, c.DisplayName)
+	}
+	return w.Flush()
+}
+
+func rotateSecret(id string) error {
+	if appRotateOutput == "" {
+		return fmt.Errorf("--output is required")
+	}
+	if appRotateRemoveOld && appRotateKeyID == "" {
+		return fmt.Errorf("--remove-old requires --key-id")
+	}
+	if appRotateLifetime <= 0 {
+		return fmt.Errorf("--lifetime must be positive")
+	}
+
+	api := setupAPI()
+	ctx := context.Background()
+
+	app, err := findApplication(ctx, api, id, []string{"id", "appId", "displayName", "passwordCredentials"})
+	if err != nil {
+		return err
+	}
+
+	var old *msgraph.PasswordCredential
+	if appRotateKeyID != "" {
+		for i, p := range app.PasswordCredentials {
+			if strings.EqualFold(p.KeyID, appRotateKeyID) {
+				old = &app.PasswordCredentials[i]
+			}
+		}
+		if old == nil {
+			return fmt.Errorf("application %v has no secret with key ID %v", app.DisplayName, appRotateKeyID)
+		}
+	}
+
+	name := appRotateName
+	if name == "" && old != nil {
+		name = old.DisplayName
+	}
+
+	// The file is created before the secret is added, so that a secret
+	// is never added without a place to keep it.
+	f, err := os.OpenFile(appRotateOutput, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	start, end := edm.NewDateTimeOffset(now), edm.NewDateTimeOffset(now.AddDate(0, 0, appRotateLifetime))
+	created, err := api.AddApplicationPassword(ctx, app.ID, msgraph.PasswordCredential{
+		DisplayName:   name,
+		StartDateTime: &start,
+		EndDateTime:   &end,
+	})
+	if err != nil {
+		f.Close()
+		os.Remove(appRotateOutput)
+		return err
+	}
+	_, err = fmt.Fprintln(f, created.SecretText)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		// The secret cannot be retrieved again, so it is of no use
+		// without the file.
+		os.Remove(appRotateOutput)
+		if rerr := api.RemoveApplicationPassword(ctx, app.ID, created.KeyID); rerr != nil {
+			return fmt.Errorf("writing the new secret: %v; removing it failed as well (%v), remove the secret with key ID %v from application %v", err, rerr, created.KeyID, app.DisplayName)
+		}
+		return fmt.Errorf("writing the new secret: %v; the secret has been removed again", err)
+	}
+	fmt.Printf("Added secret %v to application %v, valid until %v; written to %v\n",
+		created.KeyID, app.DisplayName, created.EndDateTime, appRotateOutput)
+
+	if old == nil {
+		return nil
+	}
+	own := strings.EqualFold(app.AppID, viper.GetString("clientID")) &&
+		old.Hint != "" && strings.HasPrefix(viper.GetString("clientSecret"), old.Hint)
+	if own {
+		fmt.Println("The replaced secret is the one msgraph is configured with; update clientSecret.")
+	}
+	if !appRotateRemoveOld {
+		fmt.Printf("Remove the old secret %v once it is no longer used\n", old.KeyID)
+		return nil
+	}
+	// Removing our own secret locks msgraph out until clientSecret is
+	// updated, so it takes an explicit confirmation.
+	if own && !appRotateYes && !confirm(fmt.Sprintf("Remove secret %v, which msgraph is configured with?", old.KeyID)) {
+		fmt.Printf("Kept secret %v; remove it once it is no longer used\n", old.KeyID)
+		return nil
+	}
+	if err := api.RemoveApplicationPassword(ctx, app.ID, old.KeyID); err != nil {
+		return err
+	}
+	fmt.Printf("Removed secret %v from application %v\n", old.KeyID, app.DisplayName)
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+// appGetCmd represents the app get command
+var appGetCmd = &cobra.Command{
+	Use:   "get <app> [<property>...]",
+	Short: "Get an application from the Graph API",
+	Long: `Get an application, given by its application (client) ID or object
+ID, or only the given properties of it, from the Graph API`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			fmt.Fprintln(os.Stderr, "Error: expected <app>")
+			os.Exit(1)
+		}
+		if err := getApp(args[0], args[1:]); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	appCmd.AddCommand(appGetCmd)
+}
+
+func getApp(id string, properties []string) error {
+	api := setupAPI()
+	app, err := findApplication(context.Background(), api, id, properties)
+	if err != nil {
+		return err
+	}
+
+	props, err := entityProperties(app)
+	if err != nil {
+		return err
+	}
+	writeProperties(props)
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/crosse/msgraph"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+var (
+	appListFilter            string
+	appListServicePrincipals bool
+	appListAll               bool
+	appListTop               int
+)
+
+// appListCmd represents the app list command
+var appListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List applications from the Graph API",
+	Long: `List the applications registered in the tenant, or with
+--service-principals the service principals, optionally filtered with an
+OData $filter expression. Without --all, at most --top are listed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := listApps(); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	appCmd.AddCommand(appListCmd)
+
+	appListCmd.Flags().StringVar(&appListFilter, "filter", "", "An OData $filter expression")
+	appListCmd.Flags().BoolVar(&appListServicePrincipals, "service-principals", false, "List service principals instead of applications")
+	appListCmd.Flags().BoolVar(&appListAll, "all", false, "List all matching applications")
+	appListCmd.Flags().IntVar(&appListTop, "top", 100, "The number of applications to list, or the page size with --all")
+}
+
+func listApps() error {
+	api := setupAPI()
+	ctx := context.Background()
+
+	top := pageSize(appListTop)
+	limit := appListTop
+	if appListAll {
+		limit = -1
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	n := 0
+	if appListServicePrincipals {
+		it := api.ListServicePrincipals(ctx, &msgraph.Query{
+			Filter: appListFilter,
+			Select: []string{"id", "appId", "displayName", "servicePrincipalType"},
+			Top:    top,
+		})
+		defer it.Close()
+
+		fmt.Fprintln(w, "id\tappId\tdisplayName\tservicePrincipalType")
+		for (limit < 0 || n < limit) && it.Next() {
+			sp := it.ServicePrincipal()
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", sp.ID, sp.AppID, sp.DisplayName, sp.ServicePrincipalType)
+			n++
+		}
+		w.Flush()
+		return it.Err()
+	}
+
+	it := api.ListApplications(ctx, &msgraph.Query{
+		Filter: appListFilter,
+		Select: []string{"id", "appId", "displayName", "signInAudience"},
+		Top:    top,
+	})
+	defer it.Close()
+
+	fmt.Fprintln(w, "id\tappId\tdisplayName\tsignInAudience")
+	for (limit < 0 || n < limit) && it.Next() {
+		app := it.Application()
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", app.ID, app.AppID, app.DisplayName, app.SignInAudience)
+		n++
+	}
+	w.Flush()
+	return it.Err()
+}
@@ -0,0 +1,174 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/crosse/msgraph"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+// appPermissionsCmd represents the app permissions command
+var appPermissionsCmd = &cobra.Command{
+	Use:   "permissions <app>",
+	Short: "Report the permissions of an application",
+	Long: `Report the permissions of the service principal of an application,
+given by its application (client) ID or the service principal's object
+ID: the application permissions (app roles) it holds, the delegated
+permissions granted to it, and who has been assigned its own app roles.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			fmt.Fprintln(os.Stderr, "Error: expected <app>")
+			os.Exit(1)
+		}
+		if err := reportPermissions(args[0]); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	appCmd.AddCommand(appPermissionsCmd)
+}
+
+func reportPermissions(id string) error {
+	api := setupAPI()
+	ctx := context.Background()
+
+	sp, err := api.GetServicePrincipalByAppID(ctx, id, nil)
+	if msgraph.IsNotFound(err) {
+		sp, err = api.GetServicePrincipal(ctx, id, nil)
+	}
+	if err != nil {
+		return err
+	}
+	resolver := api.NewAppRoleResolver()
+
+	fmt.Printf("Permissions of %v (appId %v, service principal %v)\n", sp.DisplayName, sp.AppID, sp.ID)
+
+	// Application permissions, grouped by resource.
+	held := make(map[string][]string)
+	assignments := api.ListServicePrincipalAppRoleAssignments(ctx, sp.ID, nil)
+	defer assignments.Close()
+	for assignments.Next() {
+		a := assignments.Assignment()
+		name, err := resolver.RoleName(ctx, a)
+		if err != nil {
+			return err
+		}
+		held[a.ResourceDisplayName] = append(held[a.ResourceDisplayName], name)
+	}
+	if err := assignments.Err(); err != nil {
+		return err
+	}
+	fmt.Println("\nApplication permissions:")
+	writePermissionGroups(held)
+
+	// Delegated permissions, grouped by resource and by whom they are
+	// granted on behalf of.
+	delegated := make(map[string][]string)
+	users := make(map[string]string)
+	grants := api.ListServicePrincipalOAuth2PermissionGrants(ctx, sp.ID, nil)
+	defer grants.Close()
+	for grants.Next() {
+		g := grants.Grant()
+		resource, err := resolver.Resource(ctx, g.ResourceID)
+		if err != nil {
+			return err
+		}
+		onBehalfOf := "all users"
+		if g.ConsentType == msgraph.ConsentTypePrincipal {
+			onBehalfOf = userName(ctx, api, users, g.PrincipalID)
+		}
+		key := fmt.Sprintf("%v, on behalf of %v", resource.DisplayName, onBehalfOf)
+		delegated[key] = append(delegated[key], g.Scopes()...)
+	}
+	if err := grants.Err(); err != nil {
+		return err
+	}
+	fmt.Println("\nDelegated permissions:")
+	writePermissionGroups(delegated)
+
+	// Principals holding the application's own app roles.
+	fmt.Println("\nApp roles assigned to others:")
+	assignedTo := api.ListAppRoleAssignedTo(ctx, sp.ID, nil)
+	defer assignedTo.Close()
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	n := 0
+	for assignedTo.Next() {
+		a := assignedTo.Assignment()
+		name, err := resolver.RoleName(ctx, a)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "  %v\t%v\t%v\t%v\n", a.PrincipalType, a.PrincipalDisplayName, a.PrincipalID, name)
+		n++
+	}
+	if err := assignedTo.Err(); err != nil {
+		return err
+	}
+	w.Flush()
+	if n == 0 {
+		fmt.Println("  (none)")
+	}
+	return nil
+}
+
+// writePermissionGroups writes each group of permissions under its
+// name, both sorted.
+func writePermissionGroups(groups map[string][]string) {
+	if len(groups) == 0 {
+		fmt.Println("  (none)")
+		return
+	}
+	var names []string
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		perms := groups[name]
+		sort.Strings(perms)
+		fmt.Printf("  %v\n    %v\n", name, strings.Join(perms, "\n    "))
+	}
+}
+
+// userName returns the user principal name of the user with the given
+// ID, or the ID if the user cannot be retrieved. Names are cached in
+// names.
+func userName(ctx context.Context, api *msgraph.GraphAPI, names map[string]string, id string) string {
+	if name, ok := names[id]; ok {
+		return name
+	}
+	name := id
+	if user, err := api.GetUser(ctx, id, []string{"userPrincipalName"}); err == nil && user.UserPrincipalName != "" {
+		name = user.UserPrincipalName
+	}
+	names[id] = name
+	return name
+}
@@ -0,0 +1,148 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/crosse/msgraph"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+var (
+	groupCreateFrom         string
+	groupCreateName         string
+	groupCreateMailNickname string
+	groupCreateDescription  string
+	groupCreateType         string
+	groupCreateRule         string
+	groupCreateVisibility   string
+	groupCreateOwners       []string
+	groupCreateMembers      []string
+)
+
+// groupCreateCmd represents the group create command
+var groupCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a group in the Graph API",
+	Long: `Create a security group or a Microsoft 365 group, either from flags
+or from a YAML or JSON file whose keys are Graph group property names,
+for example:
+
+  displayName: Sales
+  mailNickname: sales
+  mailEnabled: false
+  securityEnabled: true
+  groupTypes: [DynamicMembership]
+  membershipRule: user.department -eq "Sales"
+  membershipRuleProcessingState: "On"
+
+Flags override the properties in the file. With --rule, the group is
+made dynamic.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := createGroup(cmd); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	groupCmd.AddCommand(groupCreateCmd)
+
+	groupCreateCmd.Flags().StringVar(&groupCreateFrom, "from", "", "The YAML or JSON file describing the group")
+	groupCreateCmd.Flags().StringVar(&groupCreateName, "name", "", "The display name of the group")
+	groupCreateCmd.Flags().StringVar(&groupCreateMailNickname, "mail-nickname", "", "The mail alias of the group")
+	groupCreateCmd.Flags().StringVar(&groupCreateDescription, "description", "", "The description of the group")
+	groupCreateCmd.Flags().StringVar(&groupCreateType, "type", "security", "The type of group: security or m365")
+	groupCreateCmd.Flags().StringVar(&groupCreateRule, "rule", "", "The membership rule of a dynamic group")
+	groupCreateCmd.Flags().StringVar(&groupCreateVisibility, "visibility", "", "The visibility of a Microsoft 365 group: Public, Private or HiddenMembership")
+	groupCreateCmd.Flags().StringSliceVar(&groupCreateOwners, "owner", nil, "The ID of an owner")
+	groupCreateCmd.Flags().StringSliceVar(&groupCreateMembers, "member", nil, "The ID of a member")
+}
+
+func createGroup(cmd *cobra.Command) error {
+	var group msgraph.Group
+	if groupCreateFrom != "" {
+		if err := readYAML(groupCreateFrom, &group); err != nil {
+			return err
+		}
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("name") {
+		group.DisplayName = groupCreateName
+	}
+	if flags.Changed("mail-nickname") {
+		group.MailNickname = groupCreateMailNickname
+	}
+	if flags.Changed("description") {
+		group.Description = groupCreateDescription
+	}
+	if flags.Changed("visibility") {
+		group.Visibility = groupCreateVisibility
+	}
+	if flags.Changed("type") || groupCreateFrom == "" {
+		var m365 bool
+		switch groupCreateType {
+		case "security":
+		case "m365":
+			m365 = true
+		default:
+			return fmt.Errorf("unknown group type %q", groupCreateType)
+		}
+		security := !m365
+		group.MailEnabled = &m365
+		group.SecurityEnabled = &security
+		if m365 {
+			group.GroupTypes = appendMissing(group.GroupTypes, msgraph.GroupTypeUnified)
+		}
+	}
+	if flags.Changed("rule") {
+		group.MembershipRule = groupCreateRule
+		group.MembershipRuleProcessingState = "On"
+		group.GroupTypes = appendMissing(group.GroupTypes, msgraph.GroupTypeDynamicMembership)
+	}
+
+	api := setupAPI()
+	created, err := api.CreateGroup(context.Background(), group, &msgraph.CreateGroupOptions{
+		Owners:  groupCreateOwners,
+		Members: groupCreateMembers,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Created group %v (%v)\n", created.DisplayName, created.ID)
+	return nil
+}
+
+// appendMissing appends s to list unless it is already in it.
+func appendMissing(list []string, s string) []string {
+	for _, e := range list {
+		if e == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
@@ -0,0 +1,61 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+var groupDeleteYes bool
+
+// groupDeleteCmd represents the group delete command
+var groupDeleteCmd = &cobra.Command{
+	Use:   "delete <id>",
+	Short: "Delete a group from the Graph API",
+	Long: `Delete a group. Microsoft 365 groups can be restored for 30 days;
+security groups are deleted permanently.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			fmt.Fprintln(os.Stderr, "Error: expected <id>")
+			os.Exit(1)
+		}
+		if !groupDeleteYes && !confirm(fmt.Sprintf("Delete group %v?", args[0])) {
+			return
+		}
+
+		api := setupAPI()
+		if err := api.DeleteGroup(context.Background(), args[0]); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+		fmt.Printf("Deleted group %v\n", args[0])
+	},
+}
+
+func init() {
+	groupCmd.AddCommand(groupDeleteCmd)
+
+	groupDeleteCmd.Flags().BoolVarP(&groupDeleteYes, "yes", "y", false, "Do not ask for confirmation")
+}
@@ -0,0 +1,85 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+// groupGetCmd represents the group get command
+var groupGetCmd = &cobra.Command{
+	Use:   "get <id> [<property>...]",
+	Short: "Get a group from the Graph API",
+	Long:  `Get a group, or only the given properties of it, from the Graph API`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			fmt.Fprintln(os.Stderr, "Error: expected <id>")
+			os.Exit(1)
+		}
+		if err := getGroup(args[0], args[1:]); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	groupCmd.AddCommand(groupGetCmd)
+}
+
+func getGroup(id string, properties []string) error {
+	api := setupAPI()
+	group, err := api.GetGroup(context.Background(), id, properties)
+	if err != nil {
+		return err
+	}
+
+	props, err := entityProperties(group)
+	if err != nil {
+		return err
+	}
+	writeProperties(props)
+	return nil
+}
+
+// writeProperties writes the given properties, sorted by name, one per
+// line.
+func writeProperties(props map[string]interface{}) {
+	var names []string
+	longest := 0
+	for k := range props {
+		names = append(names, k)
+		if len(k) > longest {
+			longest = len(k)
+		}
+	}
+	sort.Strings(names)
+
+	format := fmt.Sprintf("%%-%ds: %%v\n", longest)
+	for _, name := range names {
+		fmt.Printf(format, name, formatProperty(props[name]))
+	}
+}
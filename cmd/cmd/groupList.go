@@ -0,0 +1,106 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/crosse/msgraph"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+var (
+	groupListFilter string
+	groupListSelect []string
+	groupListAll    bool
+	groupListTop    int
+)
+
+// defaultGroupColumns are the properties listed when none are selected.
+var defaultGroupColumns = []string{"id", "displayName", "mailNickname", "groupTypes"}
+
+// groupListCmd represents the group list command
+var groupListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List groups from the Graph API",
+	Long: `List the groups in the directory, optionally filtered with an OData
+$filter expression. Without --all, at most --top groups are listed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := listGroups(); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	groupCmd.AddCommand(groupListCmd)
+
+	groupListCmd.Flags().StringVar(&groupListFilter, "filter", "", "An OData $filter expression")
+	groupListCmd.Flags().StringSliceVar(&groupListSelect, "select", nil, "The properties to list")
+	groupListCmd.Flags().BoolVar(&groupListAll, "all", false, "List all matching groups")
+	groupListCmd.Flags().IntVar(&groupListTop, "top", 100, "The number of groups to list, or the page size with --all")
+}
+
+func listGroups() error {
+	api := setupAPI()
+
+	columns := groupListSelect
+	if len(columns) == 0 {
+		columns = defaultGroupColumns
+	}
+
+	it := api.ListGroups(context.Background(), &msgraph.Query{
+		Filter: groupListFilter,
+		Select: columns,
+		Top:    pageSize(groupListTop),
+	})
+	defer it.Close()
+
+	limit := groupListTop
+	if groupListAll {
+		limit = -1
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(columns, "\t"))
+
+	n := 0
+	for (limit < 0 || n < limit) && it.Next() {
+		props, err := entityProperties(it.Group())
+		if err != nil {
+			return err
+		}
+		values := make([]string, len(columns))
+		for i, c := range columns {
+			values[i] = formatProperty(props[c])
+		}
+		fmt.Fprintln(w, strings.Join(values, "\t"))
+		n++
+	}
+	w.Flush()
+
+	return it.Err()
+}
@@ -0,0 +1,165 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/crosse/msgraph"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+var (
+	groupMembersListTransitive bool
+	groupMembersListAll        bool
+	groupMembersListTop        int
+)
+
+// groupMembersCmd represents the group members command
+var groupMembersCmd = &cobra.Command{
+	Use:   "members",
+	Short: "Group membership operations",
+	Long:  `List/Add/Remove the members of a group`,
+}
+
+// groupMembersListCmd represents the group members list command
+var groupMembersListCmd = &cobra.Command{
+	Use:   "list <id>",
+	Short: "List the members of a group",
+	Long: `List the direct members of a group, or with --transitive also the
+members of nested groups. Without --all, at most --top members are
+listed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			fmt.Fprintln(os.Stderr, "Error: expected <id>")
+			os.Exit(1)
+		}
+
+		api := setupAPI()
+		q := &msgraph.Query{Top: pageSize(groupMembersListTop)}
+		var it *msgraph.DirectoryObjectIterator
+		if groupMembersListTransitive {
+			it = api.ListGroupTransitiveMembers(context.Background(), args[0], q)
+		} else {
+			it = api.ListGroupMembers(context.Background(), args[0], q)
+		}
+		defer it.Close()
+
+		limit := groupMembersListTop
+		if groupMembersListAll {
+			limit = -1
+		}
+		if err := writeDirectoryObjects(it, limit); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+	},
+}
+
+// groupMembersAddCmd represents the group members add command
+var groupMembersAddCmd = &cobra.Command{
+	Use:   "add <id> <member>...",
+	Short: "Add members to a group",
+	Long:  `Add users, groups, devices or service principals, given by their IDs, to a group`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "Error: expected <id> <member>...")
+			os.Exit(1)
+		}
+
+		api := setupAPI()
+		if err := api.AddGroupMembers(context.Background(), args[0], args[1:]); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+		fmt.Printf("Added %d member(s) to group %v\n", len(args)-1, args[0])
+	},
+}
+
+// groupMembersRemoveCmd represents the group members remove command
+var groupMembersRemoveCmd = &cobra.Command{
+	Use:   "remove <id> <member>...",
+	Short: "Remove members from a group",
+	Long:  `Remove members, given by their IDs, from a group`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "Error: expected <id> <member>...")
+			os.Exit(1)
+		}
+
+		api := setupAPI()
+		for _, member := range args[1:] {
+			if err := api.RemoveGroupMember(context.Background(), args[0], member); err != nil {
+				printError(err)
+				os.Exit(1)
+			}
+			fmt.Printf("Removed %v from group %v\n", member, args[0])
+		}
+	},
+}
+
+func init() {
+	groupCmd.AddCommand(groupMembersCmd)
+	groupMembersCmd.AddCommand(groupMembersListCmd)
+	groupMembersCmd.AddCommand(groupMembersAddCmd)
+	groupMembersCmd.AddCommand(groupMembersRemoveCmd)
+
+	groupMembersListCmd.Flags().BoolVar(&groupMembersListTransitive, "transitive", false, "Include the members of nested groups")
+	groupMembersListCmd.Flags().BoolVar(&groupMembersListAll, "all", false, "List all members")
+	groupMembersListCmd.Flags().IntVar(&groupMembersListTop, "top", 100, "The number of members to list, or the page size with --all")
+}
+
+// writeDirectoryObjects writes a table of the directory objects
+// returned by it, stopping after limit objects unless limit is
+// negative.
+func writeDirectoryObjects(it *msgraph.DirectoryObjectIterator, limit int) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "id\ttype\tdisplayName\tname")
+
+	n := 0
+	for (limit < 0 || n < limit) && it.Next() {
+		obj := it.Object()
+		typ := strings.TrimPrefix(obj.ObjectType(), "#microsoft.graph.")
+
+		var displayName, name string
+		switch o := obj.(type) {
+		case *msgraph.User:
+			displayName, name = o.DisplayName, o.UserPrincipalName
+		case *msgraph.Group:
+			displayName, name = o.DisplayName, o.MailNickname
+		case *msgraph.DirectoryRole:
+			displayName = o.DisplayName
+		case *msgraph.AdministrativeUnit:
+			displayName = o.DisplayName
+		case *msgraph.ServicePrincipal:
+			displayName, name = o.DisplayName, o.AppID
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", obj.ObjectID(), typ, displayName, name)
+		n++
+	}
+	w.Flush()
+
+	return it.Err()
+}
@@ -0,0 +1,108 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+// groupOwnersCmd represents the group owners command
+var groupOwnersCmd = &cobra.Command{
+	Use:   "owners",
+	Short: "Group ownership operations",
+	Long:  `List/Add/Remove the owners of a group`,
+}
+
+// groupOwnersListCmd represents the group owners list command
+var groupOwnersListCmd = &cobra.Command{
+	Use:   "list <id>",
+	Short: "List the owners of a group",
+	Long:  `List the owners of a group`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			fmt.Fprintln(os.Stderr, "Error: expected <id>")
+			os.Exit(1)
+		}
+
+		api := setupAPI()
+		it := api.ListGroupOwners(context.Background(), args[0], nil)
+		defer it.Close()
+		if err := writeDirectoryObjects(it, -1); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+	},
+}
+
+// groupOwnersAddCmd represents the group owners add command
+var groupOwnersAddCmd = &cobra.Command{
+	Use:   "add <id> <owner>...",
+	Short: "Add owners to a group",
+	Long:  `Add users or service principals, given by their IDs, to the owners of a group`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "Error: expected <id> <owner>...")
+			os.Exit(1)
+		}
+
+		api := setupAPI()
+		for _, owner := range args[1:] {
+			if err := api.AddGroupOwner(context.Background(), args[0], owner); err != nil {
+				printError(err)
+				os.Exit(1)
+			}
+			fmt.Printf("Added owner %v to group %v\n", owner, args[0])
+		}
+	},
+}
+
+// groupOwnersRemoveCmd represents the group owners remove command
+var groupOwnersRemoveCmd = &cobra.Command{
+	Use:   "remove <id> <owner>...",
+	Short: "Remove owners from a group",
+	Long:  `Remove owners, given by their IDs, from a group`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "Error: expected <id> <owner>...")
+			os.Exit(1)
+		}
+
+		api := setupAPI()
+		for _, owner := range args[1:] {
+			if err := api.RemoveGroupOwner(context.Background(), args[0], owner); err != nil {
+				printError(err)
+				os.Exit(1)
+			}
+			fmt.Printf("Removed owner %v from group %v\n", owner, args[0])
+		}
+	},
+}
+
+func init() {
+	groupCmd.AddCommand(groupOwnersCmd)
+	groupOwnersCmd.AddCommand(groupOwnersListCmd)
+	groupOwnersCmd.AddCommand(groupOwnersAddCmd)
+	groupOwnersCmd.AddCommand(groupOwnersRemoveCmd)
+}
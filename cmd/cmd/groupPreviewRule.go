@@ -0,0 +1,148 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/crosse/msgraph"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+var groupPreviewRuleRule string
+
+// groupPreviewRuleCmd represents the group preview-rule command
+var groupPreviewRuleCmd = &cobra.Command{
+	Use:   "preview-rule <id>",
+	Short: "Preview the members of a dynamic group",
+	Long: `Evaluate a dynamic membership rule against all users and compare the
+result with the current members of the group. Users that the rule would
+add are listed with "+", users it would remove with "-".
+
+Without --rule, the group's current membershipRule is evaluated, which
+shows members that are still being processed. The rule is evaluated
+locally and may differ from Azure AD in corner cases.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			fmt.Fprintln(os.Stderr, "Error: expected <id>")
+			os.Exit(1)
+		}
+		if err := previewRule(args[0]); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	groupCmd.AddCommand(groupPreviewRuleCmd)
+
+	groupPreviewRuleCmd.Flags().StringVar(&groupPreviewRuleRule, "rule", "", "The membership rule to preview instead of the group's")
+}
+
+func previewRule(id string) error {
+	ctx := context.Background()
+	api := setupAPI()
+
+	text := groupPreviewRuleRule
+	if text == "" {
+		group, err := api.GetGroup(ctx, id, []string{"id", "membershipRule"})
+		if err != nil {
+			return err
+		}
+		if group.MembershipRule == "" {
+			return fmt.Errorf("group %v has no membership rule; use --rule", id)
+		}
+		text = group.MembershipRule
+	}
+	rule, err := msgraph.ParseMembershipRule(text)
+	if err != nil {
+		return err
+	}
+
+	// Users are matched locally, so all of them are fetched with just
+	// the properties the rule needs.
+	props := append([]string{"id", "displayName", "userPrincipalName"}, rule.Properties()...)
+	users := api.ListUsers(ctx, &msgraph.Query{Select: props, Top: 999})
+	defer users.Close()
+	predicted := make(map[string]msgraph.User)
+	for users.Next() {
+		u := users.User()
+		ok, err := rule.Match(&u)
+		if err != nil {
+			return err
+		}
+		if ok {
+			predicted[u.ID] = u
+		}
+	}
+	if err := users.Err(); err != nil {
+		return err
+	}
+
+	members := api.ListGroupMembers(ctx, id, &msgraph.Query{Top: 999})
+	defer members.Close()
+	current := make(map[string]msgraph.User)
+	for members.Next() {
+		if u, ok := members.Object().(*msgraph.User); ok {
+			current[u.ID] = *u
+		}
+	}
+	if err := members.Err(); err != nil {
+		return err
+	}
+
+	var added, removed []msgraph.User
+	for id, u := range predicted {
+		if _, ok := current[id]; !ok {
+			added = append(added, u)
+		}
+	}
+	for id, u := range current {
+		if _, ok := predicted[id]; !ok {
+			removed = append(removed, u)
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	writeRuleChanges(w, "+", added)
+	writeRuleChanges(w, "-", removed)
+	w.Flush()
+
+	fmt.Printf("%d member(s) predicted: %d to add, %d to remove, %d unchanged\n",
+		len(predicted), len(added), len(removed), len(predicted)-len(added))
+	return nil
+}
+
+// writeRuleChanges writes users, sorted by user principal name, each
+// prefixed with mark.
+func writeRuleChanges(w *tabwriter.Writer, mark string, users []msgraph.User) {
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].UserPrincipalName < users[j].UserPrincipalName
+	})
+	for _, u := range users {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", mark, u.UserPrincipalName, u.DisplayName, u.ID)
+	}
+}
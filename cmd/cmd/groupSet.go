@@ -0,0 +1,76 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/crosse/msgraph"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+// groupSetCmd represents the group set command
+var groupSetCmd = &cobra.Command{
+	Use:   "set <id> property=value...",
+	Short: "Update properties of a group in the Graph API",
+	Long: `Update properties of a group. Only the given properties are sent.
+
+Values are parsed as JSON when possible and used as strings otherwise,
+as with "user set". "property=null" or "property=" clears the property.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := setGroup(args); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	groupCmd.AddCommand(groupSetCmd)
+}
+
+func setGroup(args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("expected <id> property=value...")
+	}
+
+	patch := msgraph.NewGroupPatch()
+	for _, arg := range args[1:] {
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("invalid assignment %q", arg)
+		}
+		if err := setPatchValue(patch, kv[0], kv[1]); err != nil {
+			return err
+		}
+	}
+
+	api := setupAPI()
+	if err := api.UpdateGroup(context.Background(), args[0], patch); err != nil {
+		return err
+	}
+
+	fmt.Printf("Updated %v of group %v\n", strings.Join(patch.Properties(), ", "), args[0])
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/crosse/msgraph"
+	"github.com/spf13/cobra"
+)
+
+// licenseCmd represents the license command
+var licenseCmd = &cobra.Command{
+	Use:   "license",
+	Short: "License management",
+	Long:  `List/Assign/Remove licenses and report license usage`,
+}
+
+func init() {
+	RootCmd.AddCommand(licenseCmd)
+}
+
+// findSKU returns the SKU among skus with the given SKU ID or part
+// number, ignoring case.
+func findSKU(skus []msgraph.SubscribedSku, idOrPartNumber string) (*msgraph.SubscribedSku, error) {
+	for i, s := range skus {
+		if strings.EqualFold(s.SKUID, idOrPartNumber) || strings.EqualFold(s.SKUPartNumber, idOrPartNumber) {
+			return &skus[i], nil
+		}
+	}
+	return nil, fmt.Errorf("the tenant has no subscription for SKU %v", idOrPartNumber)
+}
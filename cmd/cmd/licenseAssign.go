@@ -0,0 +1,96 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/crosse/msgraph"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+var licenseAssignDisabledPlans []string
+
+// licenseAssignCmd represents the license assign command
+var licenseAssignCmd = &cobra.Command{
+	Use:   "assign <user> <sku>...",
+	Short: "Assign licenses to a user",
+	Long: `Assign one or more licenses to a user. SKUs and the service plans
+to disable are given by their IDs or names, for example ENTERPRISEPACK
+and EXCHANGE_S_ENTERPRISE. Assigning a license the user already has
+replaces its disabled plans.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := assignLicenses(args); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	licenseCmd.AddCommand(licenseAssignCmd)
+
+	licenseAssignCmd.Flags().StringSliceVar(&licenseAssignDisabledPlans, "disable-plan", nil, "A service plan to disable")
+}
+
+func assignLicenses(args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("expected <user> <sku>...")
+	}
+
+	api := setupAPI()
+	ctx := context.Background()
+	skus, err := api.ListSubscribedSkus(ctx)
+	if err != nil {
+		return err
+	}
+
+	used := make(map[string]bool)
+	var add []msgraph.AssignedLicense
+	for _, arg := range args[1:] {
+		sku, err := findSKU(skus, arg)
+		if err != nil {
+			return err
+		}
+
+		l := msgraph.AssignedLicense{SKU: sku.SKUID, DisabledPlans: []string{}}
+		for _, p := range licenseAssignDisabledPlans {
+			if plan, ok := sku.ServicePlan(p); ok {
+				l.DisabledPlans = append(l.DisabledPlans, plan.ServicePlanID)
+				used[p] = true
+			}
+		}
+		add = append(add, l)
+	}
+	for _, p := range licenseAssignDisabledPlans {
+		if !used[p] {
+			return fmt.Errorf("service plan %v is not part of any of the SKUs", p)
+		}
+	}
+
+	if _, err := api.AssignLicense(ctx, args[0], add, nil); err != nil {
+		return err
+	}
+	fmt.Printf("Assigned %d license(s) to %v\n", len(add), args[0])
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/crosse/msgraph"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+// licenseListCmd represents the license list command
+var licenseListCmd = &cobra.Command{
+	Use:   "list [<user>]",
+	Short: "List licenses",
+	Long: `Without a user, list the SKUs the tenant is subscribed to. With a
+user, list the licenses assigned to the user and their disabled plans.
+Well-known SKUs and service plans are shown by their product names.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var err error
+		switch len(args) {
+		case 0:
+			err = listSubscribedSkus()
+		case 1:
+			err = listUserLicenses(args[0])
+		default:
+			err = fmt.Errorf("expected at most one <user>")
+		}
+		if err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	licenseCmd.AddCommand(licenseListCmd)
+}
+
+func listSubscribedSkus() error {
+	api := setupAPI()
+	skus, err := api.ListSubscribedSkus(context.Background())
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "skuId\tskuPartNumber\tname\tstatus")
+	for _, s := range skus {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", s.SKUID, s.SKUPartNumber, s.Name(), s.CapabilityStatus)
+	}
+	return w.Flush()
+}
+
+func listUserLicenses(id string) error {
+	api := setupAPI()
+	ctx := context.Background()
+
+	user, err := api.GetUser(ctx, id, []string{"id", "assignedLicenses"})
+	if err != nil {
+		return err
+	}
+	skus, err := api.ListSubscribedSkus(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "skuId\tname\tdisabledPlans")
+	for _, l := range user.AssignedLicenses {
+		name := l.SKU
+		var sku *msgraph.SubscribedSku
+		if sku, err = findSKU(skus, l.SKU); err == nil {
+			name = sku.Name()
+		}
+
+		disabled := make([]string, len(l.DisabledPlans))
+		for i, p := range l.DisabledPlans {
+			disabled[i] = p
+			if sku != nil {
+				if plan, ok := sku.ServicePlan(p); ok {
+					disabled[i] = plan.Name()
+					continue
+				}
+			}
+			if plan, ok := msgraph.LookupServicePlan(p); ok {
+				disabled[i] = plan.Name
+			}
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\n", l.SKU, name, strings.Join(disabled, ","))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,75 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+// licenseRemoveCmd represents the license remove command
+var licenseRemoveCmd = &cobra.Command{
+	Use:   "remove <user> <sku>...",
+	Short: "Remove licenses from a user",
+	Long: `Remove one or more licenses from a user. SKUs are given by their
+IDs or part numbers, for example ENTERPRISEPACK.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := removeLicenses(args); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	licenseCmd.AddCommand(licenseRemoveCmd)
+}
+
+func removeLicenses(args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("expected <user> <sku>...")
+	}
+
+	api := setupAPI()
+	ctx := context.Background()
+	skus, err := api.ListSubscribedSkus(ctx)
+	if err != nil {
+		return err
+	}
+
+	var remove []string
+	for _, arg := range args[1:] {
+		sku, err := findSKU(skus, arg)
+		if err != nil {
+			return err
+		}
+		remove = append(remove, sku.SKUID)
+	}
+
+	if _, err := api.AssignLicense(ctx, args[0], nil, remove); err != nil {
+		return err
+	}
+	fmt.Printf("Removed %d license(s) from %v\n", len(remove), args[0])
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+// licenseUsageCmd represents the license usage command
+var licenseUsageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Report consumed and available licenses",
+	Long: `For each SKU the tenant is subscribed to, report how many licenses
+are assigned, enabled and still available, and how many are suspended
+or in warning status.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := showLicenseUsage(); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	licenseCmd.AddCommand(licenseUsageCmd)
+}
+
+func showLicenseUsage() error {
+	api := setupAPI()
+	skus, err := api.ListSubscribedSkus(context.Background())
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "skuPartNumber\tname\tconsumed\tenabled\tavailable\tsuspended\twarning\t")
+	for _, s := range skus {
+		fmt.Fprintf(w, "%v\t%v\t%d\t%d\t%d\t%d\t%d\t\n", s.SKUPartNumber, s.Name(),
+			s.ConsumedUnits, s.PrepaidUnits.Enabled, s.Available(),
+			s.PrepaidUnits.Suspended, s.PrepaidUnits.Warning)
+	}
+	return w.Flush()
+}
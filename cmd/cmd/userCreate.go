@@ -0,0 +1,136 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/crosse/msgraph"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+	"gopkg.in/yaml.v2"
+)
+
+var userCreateFrom string
+
+// userCreateCmd represents the user create command
+var userCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a user in the Graph API",
+	Long: `Create a user from a YAML or JSON file whose keys are Graph user
+property names, for example:
+
+  accountEnabled: true
+  displayName: Jane Doe
+  mailNickname: jdoe
+  userPrincipalName: jdoe@contoso.com
+  passwordProfile:
+    password: ...
+    forceChangePasswordNextSignIn: true`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := createUser(); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	userCmd.AddCommand(userCreateCmd)
+
+	userCreateCmd.Flags().StringVar(&userCreateFrom, "from", "", "The YAML or JSON file describing the user")
+}
+
+func createUser() error {
+	if userCreateFrom == "" {
+		return fmt.Errorf("--from is required")
+	}
+
+	var user msgraph.User
+	if err := readYAML(userCreateFrom, &user); err != nil {
+		return err
+	}
+
+	api := setupAPI()
+	created, err := api.CreateUser(context.Background(), user)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Created user %v (%v)\n", created.UserPrincipalName, created.ID)
+	return nil
+}
+
+// readYAML decodes a YAML (or JSON) file into v, honouring the JSON
+// field names of v.
+func readYAML(file string, v interface{}) error {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("parsing %v: %v", file, err)
+	}
+	data, err = json.Marshal(jsonCompatible(doc))
+	if err != nil {
+		return fmt.Errorf("parsing %v: %v", file, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parsing %v: %v", file, err)
+	}
+	return nil
+}
+
+// jsonCompatible converts the maps produced by the YAML decoder into
+// maps that can be encoded as JSON.
+func jsonCompatible(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[fmt.Sprintf("%v", k)] = jsonCompatible(e)
+		}
+		return m
+	case []interface{}:
+		for i, e := range v {
+			v[i] = jsonCompatible(e)
+		}
+	}
+	return v
+}
+
+// printError writes err to stderr, listing each field of a validation
+// error on a line of its own.
+func printError(err error) {
+	if verr, ok := err.(*msgraph.ValidationError); ok {
+		fmt.Fprintln(os.Stderr, "Error: validation failed:")
+		for _, fe := range verr.Errors {
+			fmt.Fprintf(os.Stderr, "  %v\n", fe)
+		}
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+}
@@ -0,0 +1,86 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/crosse/msgraph"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+var (
+	userDeletedListFilter string
+	userDeletedListAll    bool
+	userDeletedListTop    int
+)
+
+// defaultDeletedUserColumns are the properties listed for deleted users.
+var defaultDeletedUserColumns = []string{"id", "displayName", "userPrincipalName", "deletedDateTime"}
+
+// userDeletedCmd represents the user deleted command
+var userDeletedCmd = &cobra.Command{
+	Use:   "deleted",
+	Short: "Deleted user operations",
+	Long:  `Operations on users that were deleted within the last 30 days`,
+}
+
+// userDeletedListCmd represents the user deleted list command
+var userDeletedListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List deleted users from the Graph API",
+	Long: `List the users that were deleted within the last 30 days and can
+still be restored. Without --all, at most --top users are listed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := listDeletedUsers(); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	userCmd.AddCommand(userDeletedCmd)
+	userDeletedCmd.AddCommand(userDeletedListCmd)
+
+	userDeletedListCmd.Flags().StringVar(&userDeletedListFilter, "filter", "", "An OData $filter expression")
+	userDeletedListCmd.Flags().BoolVar(&userDeletedListAll, "all", false, "List all deleted users")
+	userDeletedListCmd.Flags().IntVar(&userDeletedListTop, "top", 100, "The number of users to list, or the page size with --all")
+}
+
+func listDeletedUsers() error {
+	api := setupAPI()
+
+	top := pageSize(userDeletedListTop)
+	it := api.ListDeletedUsers(context.Background(), &msgraph.Query{
+		Filter: userDeletedListFilter,
+		Select: defaultDeletedUserColumns,
+		Top:    top,
+	})
+	defer it.Close()
+
+	limit := userDeletedListTop
+	if userDeletedListAll {
+		limit = -1
+	}
+	return writeUsers(it, defaultDeletedUserColumns, limit)
+}
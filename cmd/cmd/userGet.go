@@ -23,27 +23,42 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"sort"
 
 	"github.com/crosse/msgraph"
-	"github.com/fatih/structs"
-	"github.com/guregu/null"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"golang.org/x/net/context"
+)
+
+var (
+	userGetSelect []string
+	userGetBeta   bool
 )
 
 // userGetCmd represents the get command
 var userGetCmd = &cobra.Command{
-	Use:   "get",
+	Use:   "get <id> [<property>...]",
 	Short: "Get a user from the Graph API",
-	Long:  `Get a user from the Graph API`,
+	Long: `Get a user from the Graph API. The properties to get are given as
+arguments or with --select, as names, comma-separated lists or the
+field sets basic, contact, onprem and licensing.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		getUsers(args[0], args[1:])
+		if len(args) == 0 {
+			fmt.Fprintln(os.Stderr, "Error: expected <id>")
+			os.Exit(1)
+		}
+		if err := getUsers(args[0], append(args[1:], userGetSelect...)); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
 	},
 }
 
 func init() {
 	userCmd.AddCommand(userGetCmd)
+
+	userGetCmd.Flags().StringSliceVar(&userGetSelect, "select", nil, "The properties or field sets to get")
+	userGetCmd.Flags().BoolVar(&userGetBeta, "beta", false, "Use the beta API")
 }
 
 func setupAPI() *msgraph.GraphAPI {
@@ -56,56 +71,26 @@ func setupAPI() *msgraph.GraphAPI {
 	return api
 }
 
-func getUsers(user string, properties []string) {
+func getUsers(user string, properties []string) error {
 	api := setupAPI()
 
-	// Get the user from the Graph.
-	result, err := api.GetUser(user, properties)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v", err)
-		return
+	ctx := context.Background()
+	if userGetBeta {
+		ctx = msgraph.WithAPIVersion(ctx, msgraph.APIVersionBeta)
 	}
 
-	// Convert the object to a map.
-	m := structs.Map(result)
-
-	// Get longest property name and sort all property names
-	// alphabetically.
-	var props []string
-	longest := 0
-	for k, _ := range m {
-		props = append(props, k)
-		if len(k) > longest {
-			longest = len(k)
-		}
-	}
-	sort.Strings(props)
-
-	// Write out the properties.
-	format := fmt.Sprintf("%%-%ds: %%v\n", longest)
-	for _, prop := range props {
-		fmt.Printf(format, prop, getValueAsString(m, prop))
+	// Get the user from the Graph.
+	result, err := api.GetUser(ctx, user, properties)
+	if err != nil {
+		return err
 	}
-}
-
-func getValueAsString(user map[string]interface{}, property string) string {
-	var t interface{}
-	t = user[property]
 
-	switch t := t.(type) {
-	case null.Bool:
-		if t.Valid {
-			return fmt.Sprintf("%s", t.NullBool.Bool)
-		}
-	case null.Time:
-		if t.Valid {
-			return fmt.Sprintf("%s", t.Time)
-		}
-	case nil:
-		return ""
-	default:
-		return fmt.Sprintf("%v", t)
+	// Extension properties and properties the library does not know
+	// about are encoded alongside the others under their own names.
+	props, err := entityProperties(result)
+	if err != nil {
+		return err
 	}
-
-	return ""
+	writeProperties(props)
+	return nil
 }
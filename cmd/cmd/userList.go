@@ -0,0 +1,161 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/crosse/msgraph"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+var (
+	userListFilter string
+	userListSelect []string
+	userListAll    bool
+	userListTop    int
+)
+
+// defaultUserColumns are the properties listed when none are selected.
+var defaultUserColumns = []string{"id", "displayName", "userPrincipalName"}
+
+// userListCmd represents the user list command
+var userListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List users from the Graph API",
+	Long: `List the users in the directory, optionally filtered with an OData
+$filter expression. Without --all, at most --top users are listed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := listUsers(); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	userCmd.AddCommand(userListCmd)
+
+	userListCmd.Flags().StringVar(&userListFilter, "filter", "", "An OData $filter expression")
+	userListCmd.Flags().StringSliceVar(&userListSelect, "select", nil, "The properties or field sets to list")
+	userListCmd.Flags().BoolVar(&userListAll, "all", false, "List all matching users")
+	userListCmd.Flags().IntVar(&userListTop, "top", 100, "The number of users to list, or the page size with --all")
+}
+
+func listUsers() error {
+	api := setupAPI()
+
+	columns, warnings := msgraph.UserSelector.Resolve(userListSelect, msgraph.APIVersionV1)
+	for _, w := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", w)
+	}
+	if len(columns) == 0 {
+		columns = defaultUserColumns
+	}
+
+	it := api.ListUsers(context.Background(), &msgraph.Query{
+		Filter: userListFilter,
+		Select: columns,
+		Top:    pageSize(userListTop),
+	})
+	defer it.Close()
+
+	limit := userListTop
+	if userListAll {
+		limit = -1
+	}
+	return writeUsers(it, columns, limit)
+}
+
+// writeUsers writes a table of the given properties of the users
+// returned by it, stopping after limit users unless limit is negative.
+// maxPageSize is the largest $top Graph accepts for directory objects.
+const maxPageSize = 999
+
+// pageSize returns the $top to send for --top, which is also the number
+// of objects to list without --all.
+func pageSize(top int) int {
+	if top > maxPageSize {
+		return maxPageSize
+	}
+	return top
+}
+
+func writeUsers(it *msgraph.UserIterator, columns []string, limit int) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(columns, "\t"))
+
+	n := 0
+	for (limit < 0 || n < limit) && it.Next() {
+		props, err := entityProperties(it.User())
+		if err != nil {
+			return err
+		}
+		values := make([]string, len(columns))
+		for i, c := range columns {
+			values[i] = formatProperty(props[c])
+		}
+		fmt.Fprintln(w, strings.Join(values, "\t"))
+		n++
+	}
+	w.Flush()
+
+	return it.Err()
+}
+
+// entityProperties returns the properties of an entity, such as a
+// msgraph.User, keyed by their Graph names.
+func entityProperties(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var props map[string]interface{}
+	err = json.Unmarshal(data, &props)
+	return props, err
+}
+
+// formatProperty formats a property value decoded from JSON for
+// display.
+func formatProperty(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []interface{}:
+		s := make([]string, len(v))
+		for i, e := range v {
+			s[i] = formatProperty(e)
+		}
+		return strings.Join(s, ",")
+	case map[string]interface{}:
+		data, _ := json.Marshal(v)
+		return string(data)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
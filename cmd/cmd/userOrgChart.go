@@ -0,0 +1,189 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/crosse/msgraph"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+var (
+	userOrgChartFormat      string
+	userOrgChartDepth       int
+	userOrgChartConcurrency int
+)
+
+// orgChartProperties are the user properties shown in an org chart.
+var orgChartProperties = []string{"id", "displayName", "userPrincipalName", "jobTitle"}
+
+// userOrgChartCmd represents the user orgchart command
+var userOrgChartCmd = &cobra.Command{
+	Use:   "orgchart <id>",
+	Short: "Show the reporting lines below a user",
+	Long: `Walk the direct reports below a user and print them as a tree.
+The output format is one of "text", "json" or "dot" (Graphviz).`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := showOrgChart(args); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	userCmd.AddCommand(userOrgChartCmd)
+
+	userOrgChartCmd.Flags().StringVarP(&userOrgChartFormat, "format", "o", "text", "The output format: text, json or dot")
+	userOrgChartCmd.Flags().IntVar(&userOrgChartDepth, "depth", 0, "The number of levels to walk (0 for no limit)")
+	userOrgChartCmd.Flags().IntVar(&userOrgChartConcurrency, "concurrency", 4, "The number of requests to send at the same time")
+}
+
+func showOrgChart(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expected <id>")
+	}
+
+	var write func(io.Writer, *msgraph.OrgChartNode) error
+	switch userOrgChartFormat {
+	case "text":
+		write = writeOrgChartText
+	case "json":
+		write = writeOrgChartJSON
+	case "dot":
+		write = writeOrgChartDot
+	default:
+		return fmt.Errorf("unknown format %q", userOrgChartFormat)
+	}
+
+	api := setupAPI()
+	root, err := api.OrgChart(context.Background(), args[0], &msgraph.OrgChartOptions{
+		MaxDepth:    userOrgChartDepth,
+		Concurrency: userOrgChartConcurrency,
+		Select:      orgChartProperties,
+	})
+	if err != nil {
+		return err
+	}
+	return write(os.Stdout, root)
+}
+
+// orgChartLabel describes the user of a node on a single line.
+func orgChartLabel(n *msgraph.OrgChartNode) string {
+	label := n.User.DisplayName
+	if n.User.UserPrincipalName != "" {
+		label += fmt.Sprintf(" <%s>", n.User.UserPrincipalName)
+	}
+	if n.User.JobTitle != "" {
+		label += fmt.Sprintf(" (%s)", n.User.JobTitle)
+	}
+	switch {
+	case n.Cycle:
+		label += " [cycle]"
+	case n.Truncated:
+		label += " [...]"
+	}
+	return label
+}
+
+func writeOrgChartText(w io.Writer, root *msgraph.OrgChartNode) error {
+	fmt.Fprintln(w, orgChartLabel(root))
+	var walk func(n *msgraph.OrgChartNode, prefix string)
+	walk = func(n *msgraph.OrgChartNode, prefix string) {
+		for i, r := range n.Reports {
+			branch, indent := "├── ", "│   "
+			if i == len(n.Reports)-1 {
+				branch, indent = "└── ", "    "
+			}
+			fmt.Fprintf(w, "%s%s%s\n", prefix, branch, orgChartLabel(r))
+			walk(r, prefix+indent)
+		}
+	}
+	walk(root, "")
+	return nil
+}
+
+// orgChartJSON is the JSON representation of an org chart node.
+type orgChartJSON struct {
+	ID                string          `json:"id"`
+	DisplayName       string          `json:"displayName,omitempty"`
+	UserPrincipalName string          `json:"userPrincipalName,omitempty"`
+	JobTitle          string          `json:"jobTitle,omitempty"`
+	Cycle             bool            `json:"cycle,omitempty"`
+	Truncated         bool            `json:"truncated,omitempty"`
+	Reports           []*orgChartJSON `json:"reports,omitempty"`
+}
+
+func newOrgChartJSON(n *msgraph.OrgChartNode) *orgChartJSON {
+	j := &orgChartJSON{
+		ID:                n.User.ID,
+		DisplayName:       n.User.DisplayName,
+		UserPrincipalName: n.User.UserPrincipalName,
+		JobTitle:          n.User.JobTitle,
+		Cycle:             n.Cycle,
+		Truncated:         n.Truncated,
+	}
+	for _, r := range n.Reports {
+		j.Reports = append(j.Reports, newOrgChartJSON(r))
+	}
+	return j
+}
+
+func writeOrgChartJSON(w io.Writer, root *msgraph.OrgChartNode) error {
+	data, err := json.MarshalIndent(newOrgChartJSON(root), "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
+
+func writeOrgChartDot(w io.Writer, root *msgraph.OrgChartNode) error {
+	quote := func(s string) string {
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+	}
+
+	fmt.Fprintln(w, "digraph orgchart {")
+	fmt.Fprintln(w, "\tnode [shape=box];")
+	var walk func(n *msgraph.OrgChartNode)
+	walk = func(n *msgraph.OrgChartNode) {
+		if !n.Cycle {
+			label := n.User.DisplayName
+			if n.User.JobTitle != "" {
+				label += "\n" + n.User.JobTitle
+			}
+			fmt.Fprintf(w, "\t%s [label=%s];\n", quote(n.User.ID), strings.Replace(quote(label), "\n", `\n`, -1))
+		}
+		for _, r := range n.Reports {
+			fmt.Fprintf(w, "\t%s -> %s;\n", quote(n.User.ID), quote(r.User.ID))
+			walk(r)
+		}
+	}
+	walk(root)
+	fmt.Fprintln(w, "}")
+	return nil
+}
@@ -0,0 +1,172 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"os"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+var (
+	userPhotoGetOutput string
+	userPhotoGetSize   string
+	userPhotoGetInfo   bool
+	userPhotoDeleteYes bool
+)
+
+// userPhotoCmd represents the user photo command
+var userPhotoCmd = &cobra.Command{
+	Use:   "photo",
+	Short: "User photo operations",
+	Long:  `Get/Set/Delete/Sync user photos`,
+}
+
+// userPhotoGetCmd represents the user photo get command
+var userPhotoGetCmd = &cobra.Command{
+	Use:   "get <id>",
+	Short: "Download a user's photo",
+	Long: `Download a user's photo, by default into "<id>.<ext>" in the current
+directory. Use "-o -" to write it to standard output, and --info to
+show the photo's metadata instead.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := getUserPhoto(args); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+	},
+}
+
+// userPhotoSetCmd represents the user photo set command
+var userPhotoSetCmd = &cobra.Command{
+	Use:   "set <id> <file>",
+	Short: "Upload a user's photo",
+	Long: `Upload a JPEG, PNG, GIF or BMP image of at most 4 MB as a user's
+photo.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 2 {
+			fmt.Fprintln(os.Stderr, "Error: expected <id> <file>")
+			os.Exit(1)
+		}
+		data, err := ioutil.ReadFile(args[1])
+		if err == nil {
+			err = setupAPI().SetUserPhoto(context.Background(), args[0], data)
+		}
+		if err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+		fmt.Printf("Set photo of user %v\n", args[0])
+	},
+}
+
+// userPhotoDeleteCmd represents the user photo delete command
+var userPhotoDeleteCmd = &cobra.Command{
+	Use:   "delete <id>",
+	Short: "Delete a user's photo",
+	Long:  `Delete a user's photo.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			fmt.Fprintln(os.Stderr, "Error: expected <id>")
+			os.Exit(1)
+		}
+		if !userPhotoDeleteYes && !confirm(fmt.Sprintf("Delete the photo of user %v?", args[0])) {
+			return
+		}
+
+		if err := setupAPI().DeleteUserPhoto(context.Background(), args[0]); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+		fmt.Printf("Deleted photo of user %v\n", args[0])
+	},
+}
+
+func init() {
+	userCmd.AddCommand(userPhotoCmd)
+	userPhotoCmd.AddCommand(userPhotoGetCmd)
+	userPhotoCmd.AddCommand(userPhotoSetCmd)
+	userPhotoCmd.AddCommand(userPhotoDeleteCmd)
+
+	userPhotoGetCmd.Flags().StringVarP(&userPhotoGetOutput, "output", "o", "", "The file to write the photo to")
+	userPhotoGetCmd.Flags().StringVar(&userPhotoGetSize, "size", "", "The size of the photo, for example 240x240")
+	userPhotoGetCmd.Flags().BoolVar(&userPhotoGetInfo, "info", false, "Show the photo's metadata")
+	userPhotoDeleteCmd.Flags().BoolVarP(&userPhotoDeleteYes, "yes", "y", false, "Do not ask for confirmation")
+}
+
+func getUserPhoto(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expected <id>")
+	}
+
+	api := setupAPI()
+	ctx := context.Background()
+
+	if userPhotoGetInfo {
+		photo, err := api.GetUserPhotoMetadata(ctx, args[0], userPhotoGetSize)
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(photo)
+		if err != nil {
+			return err
+		}
+		printJSON(data)
+		return nil
+	}
+
+	data, contentType, err := api.GetUserPhoto(ctx, args[0], userPhotoGetSize)
+	if err != nil {
+		return err
+	}
+
+	file := userPhotoGetOutput
+	switch file {
+	case "-":
+		_, err = os.Stdout.Write(data)
+		return err
+	case "":
+		file = args[0] + photoExtension(contentType)
+	}
+	if err := ioutil.WriteFile(file, data, 0644); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote photo of user %v to %v\n", args[0], file)
+	return nil
+}
+
+// photoExtension returns the file name extension for a photo of the
+// given content type.
+func photoExtension(contentType string) string {
+	if contentType == "image/jpeg" {
+		// mime prefers the rarely used ".jfif" on some systems.
+		return ".jpg"
+	}
+	if exts, err := mime.ExtensionsByType(contentType); err == nil && len(exts) > 0 {
+		return exts[0]
+	}
+	return ".jpg"
+}
@@ -0,0 +1,171 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+var (
+	userPhotoSyncState  string
+	userPhotoSyncDryRun bool
+	userPhotoSyncForce  bool
+)
+
+// photoSyncStateFile is the default name of the file, in the synced
+// directory, that records the hashes of the uploaded photos.
+const photoSyncStateFile = ".msgraph-photos.json"
+
+// photoFileExtensions are the extensions of the files photo sync
+// uploads.
+var photoFileExtensions = map[string]bool{".jpg": true, ".jpeg": true, ".png": true}
+
+// userPhotoSyncCmd represents the user photo sync command
+var userPhotoSyncCmd = &cobra.Command{
+	Use:   "sync <dir>",
+	Short: "Upload a directory of user photos",
+	Long: `Upload the photos in a directory, named "<upn>.jpg" (or .jpeg or .png),
+as the photos of the users with those user principal names.
+
+The SHA-256 hash of each uploaded photo is recorded in a state file,
+by default ".msgraph-photos.json" in the directory, and photos that
+have not changed since they were last uploaded are skipped. Use
+--force to upload all photos.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			fmt.Fprintln(os.Stderr, "Error: expected <dir>")
+			os.Exit(1)
+		}
+		if err := syncUserPhotos(args[0]); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	userPhotoCmd.AddCommand(userPhotoSyncCmd)
+
+	userPhotoSyncCmd.Flags().StringVar(&userPhotoSyncState, "state", "", "The state file (default <dir>/"+photoSyncStateFile+")")
+	userPhotoSyncCmd.Flags().BoolVarP(&userPhotoSyncDryRun, "dry-run", "n", false, "Only show which photos would be uploaded")
+	userPhotoSyncCmd.Flags().BoolVar(&userPhotoSyncForce, "force", false, "Upload photos even if they have not changed")
+}
+
+func syncUserPhotos(dir string) (err error) {
+	stateFile := userPhotoSyncState
+	if stateFile == "" {
+		stateFile = filepath.Join(dir, photoSyncStateFile)
+	}
+
+	// The state maps user principal names to photo hashes.
+	state := make(map[string]string)
+	if data, err := ioutil.ReadFile(stateFile); err == nil {
+		if err := json.Unmarshal(data, &state); err != nil {
+			return fmt.Errorf("reading %v: %v", stateFile, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	var names []string
+	for _, f := range files {
+		if !f.IsDir() && photoFileExtensions[strings.ToLower(filepath.Ext(f.Name()))] {
+			names = append(names, f.Name())
+		}
+	}
+	sort.Strings(names)
+
+	api := setupAPI()
+	ctx := context.Background()
+
+	var uploaded, skipped, failed int
+
+	// The state is saved on every return, so that the photos uploaded
+	// before an error are not uploaded again by the next run.
+	defer func() {
+		if userPhotoSyncDryRun || uploaded == 0 {
+			return
+		}
+		if serr := writePhotoSyncState(stateFile, state); serr != nil && err == nil {
+			err = serr
+		}
+	}()
+
+	for _, name := range names {
+		upn := strings.TrimSuffix(name, filepath.Ext(name))
+
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		hash := hex.EncodeToString(sum[:])
+
+		if !userPhotoSyncForce && state[upn] == hash {
+			skipped++
+			continue
+		}
+		if userPhotoSyncDryRun {
+			fmt.Printf("Would upload %v for %v\n", name, upn)
+			uploaded++
+			continue
+		}
+
+		if err := api.SetUserPhoto(ctx, upn, data); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v: %v\n", upn, err)
+			failed++
+			continue
+		}
+		fmt.Printf("Uploaded %v for %v\n", name, upn)
+		state[upn] = hash
+		uploaded++
+	}
+
+	fmt.Printf("%d uploaded, %d unchanged, %d failed\n", uploaded, skipped, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d photo(s) failed to upload", failed)
+	}
+	return nil
+}
+
+// writePhotoSyncState writes the photo hashes of state to stateFile.
+func writePhotoSyncState(stateFile string, state map[string]string) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(stateFile, data, 0644)
+}
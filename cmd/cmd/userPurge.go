@@ -0,0 +1,71 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+var userPurgeYes bool
+
+// userPurgeCmd represents the user purge command
+var userPurgeCmd = &cobra.Command{
+	Use:   "purge <id>",
+	Short: "Permanently delete a deleted user from the Graph API",
+	Long: `Permanently delete a user that was already deleted. A purged user
+cannot be restored.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			fmt.Fprintln(os.Stderr, "Error: expected <id>")
+			os.Exit(1)
+		}
+		if !userPurgeYes && !confirm(fmt.Sprintf("Permanently delete user %v?", args[0])) {
+			return
+		}
+
+		api := setupAPI()
+		if err := api.PurgeDeletedUser(context.Background(), args[0]); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+		fmt.Printf("Purged user %v\n", args[0])
+	},
+}
+
+func init() {
+	userCmd.AddCommand(userPurgeCmd)
+
+	userPurgeCmd.Flags().BoolVarP(&userPurgeYes, "yes", "y", false, "Do not ask for confirmation")
+}
+
+// confirm asks the user a yes/no question on the terminal.
+func confirm(question string) bool {
+	fmt.Fprintf(os.Stderr, "%s [y/N] ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
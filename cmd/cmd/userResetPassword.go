@@ -0,0 +1,100 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/crosse/msgraph"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+var (
+	userResetPasswordStdin         bool
+	userResetPasswordLength        int
+	userResetPasswordNoForceChange bool
+	userResetPasswordMfa           bool
+	userResetPasswordPolicies      string
+)
+
+// userResetPasswordCmd represents the user reset-password command
+var userResetPasswordCmd = &cobra.Command{
+	Use:   "reset-password <id>",
+	Short: "Reset a user's password",
+	Long: `Reset a user's password. Unless --password-stdin is given, a random
+password that satisfies the user's password policies is generated and
+printed. The user must change the password on the next login unless
+--no-force-change is given.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := resetPassword(cmd, args); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	userCmd.AddCommand(userResetPasswordCmd)
+
+	userResetPasswordCmd.Flags().BoolVar(&userResetPasswordStdin, "password-stdin", false, "Read the new password from standard input")
+	userResetPasswordCmd.Flags().IntVar(&userResetPasswordLength, "length", 16, "The length of a generated password")
+	userResetPasswordCmd.Flags().BoolVar(&userResetPasswordNoForceChange, "no-force-change", false, "Do not make the user change the password on the next login")
+	userResetPasswordCmd.Flags().BoolVar(&userResetPasswordMfa, "require-mfa", false, "Require multi-factor authentication to change the password")
+	userResetPasswordCmd.Flags().StringVar(&userResetPasswordPolicies, "policies", "", "Set the user's password policies, for example DisablePasswordExpiration")
+}
+
+func resetPassword(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expected <id>")
+	}
+
+	opts := msgraph.ResetPasswordOptions{
+		Length:             userResetPasswordLength,
+		ForceChange:        !userResetPasswordNoForceChange,
+		ForceChangeWithMfa: userResetPasswordMfa,
+	}
+	if cmd.Flags().Changed("policies") {
+		opts.PasswordPolicies = &userResetPasswordPolicies
+	}
+	if userResetPasswordStdin {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("reading password: %v", err)
+		}
+		opts.Password = strings.TrimRight(line, "\r\n")
+	}
+
+	api := setupAPI()
+	password, err := api.ResetPassword(context.Background(), args[0], opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Reset password of user %v\n", args[0])
+	if !userResetPasswordStdin {
+		fmt.Println(password)
+	}
+	return nil
+}
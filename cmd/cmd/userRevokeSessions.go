@@ -0,0 +1,63 @@
+// Copyright © 2016 Seth Wright <seth@crosse.org>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+// userRevokeSessionsCmd represents the user revoke-sessions command
+var userRevokeSessionsCmd = &cobra.Command{
+	Use:   "revoke-sessions <id>...",
+	Short: "Sign users out of all sessions",
+	Long: `Revoke the refresh tokens and session cookies of one or more users,
+which makes them sign in again in every application. Access tokens that
+were already issued stay valid until they expire, usually within an
+hour.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			fmt.Fprintln(os.Stderr, "Error: expected <id>...")
+			os.Exit(1)
+		}
+
+		api := setupAPI()
+		failed := false
+		for _, id := range args {
+			if err := api.RevokeSignInSessions(context.Background(), id); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v: %v\n", id, err)
+				failed = true
+				continue
+			}
+			fmt.Printf("Revoked sessions of user %v\n", id)
+		}
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	userCmd.AddCommand(userRevokeSessionsCmd)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// The subset of the OData CSDL (Common Schema Definition Language) XML
+// format that Microsoft Graph uses to describe its $metadata.
+
+type edmx struct {
+	XMLName      xml.Name `xml:"Edmx"`
+	DataServices struct {
+		Schemas []*schema `xml:"Schema"`
+	} `xml:"DataServices"`
+}
+
+type schema struct {
+	Namespace    string        `xml:"Namespace,attr"`
+	Alias        string        `xml:"Alias,attr"`
+	EnumTypes    []*enumType   `xml:"EnumType"`
+	EntityTypes  []*structType `xml:"EntityType"`
+	ComplexTypes []*structType `xml:"ComplexType"`
+}
+
+type enumType struct {
+	Name    string `xml:"Name,attr"`
+	IsFlags bool   `xml:"IsFlags,attr"`
+	Members []struct {
+		Name  string `xml:"Name,attr"`
+		Value string `xml:"Value,attr"`
+	} `xml:"Member"`
+}
+
+// structType is either an EntityType or a ComplexType.
+type structType struct {
+	Name       string      `xml:"Name,attr"`
+	BaseType   string      `xml:"BaseType,attr"`
+	Abstract   bool        `xml:"Abstract,attr"`
+	OpenType   bool        `xml:"OpenType,attr"`
+	Properties []*property `xml:"Property"`
+	Navigation []*property `xml:"NavigationProperty"`
+}
+
+type property struct {
+	Name     string `xml:"Name,attr"`
+	Type     string `xml:"Type,attr"`
+	Nullable string `xml:"Nullable,attr"`
+}
+
+// nullable reports whether the property may be null. CSDL properties
+// are nullable unless declared otherwise.
+func (p *property) nullable() bool {
+	return p.Nullable != "false"
+}
+
+// elemType returns the type of the property and whether it is a
+// collection of that type.
+func (p *property) elemType() (string, bool) {
+	if strings.HasPrefix(p.Type, "Collection(") && strings.HasSuffix(p.Type, ")") {
+		return p.Type[len("Collection(") : len(p.Type)-1], true
+	}
+	return p.Type, false
+}
+
+func parseMetadata(r io.Reader) (*edmx, error) {
+	var doc edmx
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
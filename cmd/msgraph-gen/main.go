@@ -0,0 +1,373 @@
+// Command msgraph-gen generates Go types for Microsoft Graph entities
+// from a local copy of the Graph CSDL $metadata document, which can be
+// downloaded from https://graph.microsoft.com/v1.0/$metadata (or
+// .../beta/$metadata). It is meant to be run through go generate.
+//
+// Usage:
+//
+//	msgraph-gen -metadata v1.0.xml -package v1 -o models.go
+//
+// Entity and complex types become structs, with base types embedded,
+// and enum types become string types with one constant per member.
+// Nullable scalar properties are pointers, so that a null value can be
+// told apart from a zero value, and so are all Edm date, time, duration
+// and GUID properties, so that unset ones are omitted. Navigation
+// properties, which Graph only returns when they are expanded with
+// $expand, become fields with Get accessors.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+var (
+	metadataFile = flag.String("metadata", "", "The CSDL $metadata file to read")
+	packageName  = flag.String("package", "", "The name of the generated package")
+	outputFile   = flag.String("o", "", "The file to write (default stdout)")
+	namespace    = flag.String("namespace", "microsoft.graph", "The schema namespace whose types are not prefixed")
+)
+
+func main() {
+	flag.Parse()
+	if *metadataFile == "" || *packageName == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "msgraph-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	f, err := os.Open(*metadataFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	doc, err := parseMetadata(f)
+	if err != nil {
+		return fmt.Errorf("parsing %v: %v", *metadataFile, err)
+	}
+
+	g := newGenerator(doc, *namespace)
+	src, err := g.generate(*packageName)
+	if err != nil {
+		return err
+	}
+
+	if *outputFile == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return ioutil.WriteFile(*outputFile, src, 0644)
+}
+
+// typeKind tells how a named CSDL type is represented in Go.
+type typeKind int
+
+const (
+	kindEnum typeKind = iota
+	kindComplex
+	kindEntity
+)
+
+type namedType struct {
+	kind   typeKind
+	goName string
+	schema *schema
+	enum   *enumType
+	st     *structType
+}
+
+type generator struct {
+	primary string
+	types   map[string]*namedType // by namespace- and alias-qualified name
+	order   []*namedType
+	buf     bytes.Buffer
+	imports map[string]bool
+}
+
+func newGenerator(doc *edmx, primary string) *generator {
+	g := &generator{
+		primary: primary,
+		types:   make(map[string]*namedType),
+		imports: make(map[string]bool),
+	}
+
+	for _, s := range doc.DataServices.Schemas {
+		add := func(name string, t *namedType) {
+			t.schema = s
+			t.goName = g.goTypeName(s.Namespace, name)
+			g.types[s.Namespace+"."+name] = t
+			if s.Alias != "" {
+				g.types[s.Alias+"."+name] = t
+			}
+			g.order = append(g.order, t)
+		}
+		for _, e := range s.EnumTypes {
+			add(e.Name, &namedType{kind: kindEnum, enum: e})
+		}
+		for _, c := range s.ComplexTypes {
+			add(c.Name, &namedType{kind: kindComplex, st: c})
+		}
+		for _, e := range s.EntityTypes {
+			add(e.Name, &namedType{kind: kindEntity, st: e})
+		}
+	}
+
+	sort.SliceStable(g.order, func(i, j int) bool {
+		return g.order[i].goName < g.order[j].goName
+	})
+	return g
+}
+
+// goTypeName returns the Go name of a type declared in namespace. Types
+// outside the primary namespace are prefixed with the last element of
+// their namespace, so that microsoft.graph.security.case becomes
+// SecurityCase.
+func (g *generator) goTypeName(namespace, name string) string {
+	if namespace == g.primary {
+		return exported(name)
+	}
+	ns := namespace
+	if i := strings.LastIndexByte(ns, '.'); i >= 0 {
+		ns = ns[i+1:]
+	}
+	return exported(ns) + exported(name)
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) generate(pkg string) ([]byte, error) {
+	for _, t := range g.order {
+		var err error
+		switch t.kind {
+		case kindEnum:
+			g.genEnum(t)
+		default:
+			err = g.genStruct(t)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by msgraph-gen from %v; DO NOT EDIT.\n\n", filepath.Base(*metadataFile))
+	fmt.Fprintf(&out, "package %s\n\n", pkg)
+	if len(g.imports) > 0 {
+		var imports []string
+		for imp := range g.imports {
+			imports = append(imports, imp)
+		}
+		sort.Strings(imports)
+		out.WriteString("import (\n")
+		for _, imp := range imports {
+			fmt.Fprintf(&out, "\t%q\n", imp)
+		}
+		out.WriteString(")\n\n")
+	}
+	out.Write(g.buf.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %v", err)
+	}
+	return src, nil
+}
+
+func (g *generator) genEnum(t *namedType) {
+	g.printf("// %s is the %s.%s enumeration.\n", t.goName, t.schema.Namespace, t.enum.Name)
+	if t.enum.IsFlags {
+		g.printf("// It is a flags enumeration; values are comma-separated lists of members.\n")
+	}
+	g.printf("type %s string\n\n", t.goName)
+
+	if len(t.enum.Members) == 0 {
+		return
+	}
+	g.printf("// Members of %s.\n", t.goName)
+	g.printf("const (\n")
+	for _, m := range t.enum.Members {
+		g.printf("\t%s%s %s = %q\n", t.goName, exported(m.Name), t.goName, m.Name)
+	}
+	g.printf(")\n\n")
+}
+
+func (g *generator) genStruct(t *namedType) error {
+	kind := "complex type"
+	if t.kind == kindEntity {
+		kind = "entity"
+	}
+	g.printf("// %s is the %s.%s %s.\n", t.goName, t.schema.Namespace, t.st.Name, kind)
+	if t.st.Abstract {
+		g.printf("// It is abstract; Graph only returns types derived from it.\n")
+	}
+	g.printf("type %s struct {\n", t.goName)
+
+	if t.st.BaseType != "" {
+		base, ok := g.types[t.st.BaseType]
+		if !ok {
+			return fmt.Errorf("%v: unknown base type %v", t.goName, t.st.BaseType)
+		}
+		g.printf("\t%s\n\n", base.goName)
+	}
+
+	for _, p := range t.st.Properties {
+		typ, ok, err := g.goFieldType(p, false)
+		if err != nil {
+			return fmt.Errorf("%v.%v: %v", t.goName, p.Name, err)
+		}
+		if !ok {
+			continue
+		}
+		g.printf("\t%s %s `json:\"%s,omitempty\"`\n", exported(p.Name), typ, p.Name)
+	}
+
+	if len(t.st.Navigation) > 0 {
+		g.printf("\n\t// Navigation properties, populated only when expanded.\n")
+	}
+	for _, p := range t.st.Navigation {
+		typ, _, err := g.goFieldType(p, true)
+		if err != nil {
+			return fmt.Errorf("%v.%v: %v", t.goName, p.Name, err)
+		}
+		g.printf("\t%s %s `json:\"%s,omitempty\"`\n", exported(p.Name), typ, p.Name)
+	}
+	g.printf("}\n\n")
+
+	for _, p := range t.st.Navigation {
+		typ, _, _ := g.goFieldType(p, true)
+		name := exported(p.Name)
+		g.printf("// Get%s returns the %s navigation property of the %s.\n", name, p.Name, t.st.Name)
+		g.printf("func (e *%s) Get%s() %s {\n", t.goName, name, typ)
+		g.printf("\tif e == nil {\n\t\treturn nil\n\t}\n")
+		g.printf("\treturn e.%s\n}\n\n", name)
+	}
+	return nil
+}
+
+// goFieldType returns the Go type of a property. It returns false if
+// the property cannot be represented in a JSON payload.
+func (g *generator) goFieldType(p *property, navigation bool) (string, bool, error) {
+	elem, collection := p.elemType()
+
+	if t, ok := g.types[elem]; ok {
+		switch {
+		case collection:
+			return "[]" + t.goName, true, nil
+		case t.kind != kindEntity && !p.nullable():
+			return t.goName, true, nil
+		default:
+			return "*" + t.goName, true, nil
+		}
+	}
+	if navigation {
+		return "", false, fmt.Errorf("unknown type %v", elem)
+	}
+
+	var typ string
+	var edm bool
+	switch elem {
+	case "Edm.String":
+		typ = "string"
+	case "Edm.Boolean":
+		typ = "bool"
+	case "Edm.Byte":
+		typ = "uint8"
+	case "Edm.SByte":
+		typ = "int8"
+	case "Edm.Int16":
+		typ = "int16"
+	case "Edm.Int32":
+		typ = "int32"
+	case "Edm.Int64":
+		typ = "int64"
+	case "Edm.Single":
+		typ = "float32"
+	case "Edm.Double", "Edm.Decimal":
+		typ = "float64"
+	case "Edm.Date", "Edm.DateTimeOffset", "Edm.Duration", "Edm.TimeOfDay", "Edm.Guid":
+		g.imports["github.com/crosse/msgraph/edm"] = true
+		typ = "edm." + strings.TrimPrefix(elem, "Edm.")
+		edm = true
+	case "Edm.Binary":
+		// encoding/json uses base64 for []byte, as does OData.
+		return "[]byte", true, nil
+	case "Edm.Stream":
+		// Streams are only available through their own URL.
+		return "", false, nil
+	default:
+		if strings.HasPrefix(elem, "Edm.") {
+			// Geography, geometry and untyped values.
+			g.imports["encoding/json"] = true
+			return "json.RawMessage", true, nil
+		}
+		return "", false, fmt.Errorf("unknown type %v", elem)
+	}
+
+	switch {
+	case collection:
+		return "[]" + typ, true, nil
+	case p.nullable() || edm:
+		// encoding/json never omits a struct, so the edm types are
+		// always pointers for omitempty to leave them out.
+		return "*" + typ, true, nil
+	}
+	return typ, true, nil
+}
+
+// initialisms are rendered in upper case in Go identifiers.
+var initialisms = map[string]bool{
+	"Api": true, "Html": true, "Http": true, "Id": true, "Ip": true,
+	"Json": true, "Sku": true, "Sql": true, "Uri": true, "Url": true,
+}
+
+// exported turns a camelCase CSDL name into an exported Go identifier.
+func exported(name string) string {
+	var words []string
+	start := 0
+	runes := []rune(name)
+	for i := 1; i < len(runes); i++ {
+		if unicode.IsUpper(runes[i]) && !unicode.IsUpper(runes[i-1]) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	words = append(words, string(runes[start:]))
+
+	var b strings.Builder
+	for _, w := range words {
+		r := []rune(w)
+		r[0] = unicode.ToUpper(r[0])
+		w = string(r)
+		if initialisms[w] {
+			w = strings.ToUpper(w)
+		}
+		for _, c := range w {
+			if unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' {
+				b.WriteRune(c)
+			}
+		}
+	}
+
+	s := b.String()
+	if s == "" || unicode.IsDigit([]rune(s)[0]) {
+		s = "X" + s
+	}
+	return s
+}
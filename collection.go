@@ -0,0 +1,264 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/net/context"
+)
+
+// Collection iterates over the items of a Graph collection response.
+// Pages are decoded as a stream, one item at a time, so the memory used
+// does not depend on the size of a page; when a page is exhausted the
+// next one is requested by following its @odata.nextLink.
+//
+// A Collection is used like a bufio.Scanner:
+//
+//	for c.Next() {
+//		var u User
+//		if err := c.Decode(&u); err != nil {
+//			...
+//		}
+//	}
+//	if err := c.Err(); err != nil {
+//		...
+//	}
+type Collection struct {
+	api      *GraphAPI
+	ctx      context.Context
+	resource Resource
+
+	next     string
+	resp     *http.Response
+	dec      *json.Decoder
+	inValue  bool
+	pending  bool
+	count    int64
+	hasCount bool
+	err      error
+	span     trace.Span
+}
+
+// newCollection returns a Collection over the items found at endpoint,
+// traced as the named operation until the iteration ends. No request is
+// sent until Next is called.
+func (api *GraphAPI) newCollection(ctx context.Context, operation string, r Resource, endpoint string) *Collection {
+	ctx, span := api.startOperation(ctx, operation, r)
+	return &Collection{
+		api:      api,
+		ctx:      ctx,
+		resource: r,
+		next:     endpoint,
+		span:     span,
+	}
+}
+
+// errCollection returns a Collection that fails with err.
+func errCollection(err error) *Collection {
+	return &Collection{err: err}
+}
+
+// Next advances the Collection to the next item, which can then be read
+// with Decode. It returns false when there are no more items or an error
+// occurred; Err tells the two apart.
+func (c *Collection) Next() (ok bool) {
+	defer func() {
+		if !ok {
+			c.Close()
+		}
+	}()
+	if c.err != nil {
+		return false
+	}
+
+	if c.pending {
+		// The caller skipped the current item.
+		var skip json.RawMessage
+		if c.err = c.dec.Decode(&skip); c.err != nil {
+			return false
+		}
+		c.pending = false
+	}
+
+	for {
+		if c.inValue {
+			if c.dec.More() {
+				c.pending = true
+				return true
+			}
+			if c.err = c.endPage(); c.err != nil {
+				return false
+			}
+		}
+		if c.next == "" {
+			return false
+		}
+		if c.err = c.startPage(); c.err != nil {
+			return false
+		}
+	}
+}
+
+// Decode decodes the current item into v.
+func (c *Collection) Decode(v interface{}) error {
+	if !c.pending {
+		return &GraphAPIError{"Decode called without a pending item", nil}
+	}
+	c.pending = false
+	if err := c.dec.Decode(v); err != nil {
+		c.err = &GraphAPIError{fmt.Sprintf("Decoding collection item: %v", err), err}
+		return c.err
+	}
+	return nil
+}
+
+// Count returns the total number of items reported by Graph through
+// @odata.count. It is only available once the first page has been
+// requested, and only if the count was asked for.
+func (c *Collection) Count() (int64, bool) {
+	return c.count, c.hasCount
+}
+
+// Err returns the first error encountered while iterating.
+func (c *Collection) Err() error {
+	return c.err
+}
+
+// Close stops the iteration and releases the current page's response.
+func (c *Collection) Close() error {
+	c.next = ""
+	c.inValue = false
+	c.pending = false
+	if c.span != nil {
+		endOperation(c.span, c.err)
+		c.span = nil
+	}
+	if c.resp == nil {
+		return nil
+	}
+	err := c.resp.Body.Close()
+	c.resp = nil
+	return err
+}
+
+// startPage requests the page at c.next and positions the decoder on its
+// first item.
+func (c *Collection) startPage() error {
+	req, err := http.NewRequest("GET", c.next, nil)
+	if err != nil {
+		return err
+	}
+	c.next = ""
+
+	resp, err := c.api.do(c.resource, req.WithContext(c.ctx))
+	if err != nil {
+		return err
+	}
+	if err := checkResponse(resp); err != nil {
+		resp.Body.Close()
+		return err
+	}
+	c.resp = resp
+	c.dec = json.NewDecoder(resp.Body)
+
+	if err := c.expectDelim('{'); err != nil {
+		return err
+	}
+	for c.dec.More() {
+		key, err := c.readKey()
+		if err != nil {
+			return err
+		}
+		if key == "value" {
+			if err := c.expectDelim('['); err != nil {
+				return err
+			}
+			c.inValue = true
+			return nil
+		}
+		if err := c.readProperty(key); err != nil {
+			return err
+		}
+	}
+	// A page without a value array.
+	return c.finishPage()
+}
+
+// endPage consumes the remainder of the current page after its value
+// array.
+func (c *Collection) endPage() error {
+	c.inValue = false
+	if err := c.expectDelim(']'); err != nil {
+		return err
+	}
+	for c.dec.More() {
+		key, err := c.readKey()
+		if err != nil {
+			return err
+		}
+		if err := c.readProperty(key); err != nil {
+			return err
+		}
+	}
+	return c.finishPage()
+}
+
+// finishPage consumes the end of the current page and closes its
+// response.
+func (c *Collection) finishPage() error {
+	if err := c.expectDelim('}'); err != nil {
+		return err
+	}
+	err := c.resp.Body.Close()
+	c.resp = nil
+	return err
+}
+
+// readProperty reads the value of a page-level property, keeping the
+// annotations needed to continue the iteration.
+func (c *Collection) readProperty(key string) error {
+	var err error
+	switch key {
+	case "@odata.nextLink":
+		err = c.dec.Decode(&c.next)
+	case "@odata.count":
+		if !c.hasCount {
+			err = c.dec.Decode(&c.count)
+			c.hasCount = err == nil
+			break
+		}
+		fallthrough
+	default:
+		var skip json.RawMessage
+		err = c.dec.Decode(&skip)
+	}
+	if err != nil {
+		return &GraphAPIError{fmt.Sprintf("Decoding %v: %v", key, err), err}
+	}
+	return nil
+}
+
+func (c *Collection) readKey() (string, error) {
+	t, err := c.dec.Token()
+	if err != nil {
+		return "", &GraphAPIError{fmt.Sprintf("Decoding collection: %v", err), err}
+	}
+	key, ok := t.(string)
+	if !ok {
+		return "", &GraphAPIError{fmt.Sprintf("Decoding collection: unexpected %v", t), nil}
+	}
+	return key, nil
+}
+
+func (c *Collection) expectDelim(d json.Delim) error {
+	t, err := c.dec.Token()
+	if err != nil {
+		return &GraphAPIError{fmt.Sprintf("Decoding collection: %v", err), err}
+	}
+	if t != d {
+		return &GraphAPIError{fmt.Sprintf("Decoding collection: expected %v, got %v", d, t), nil}
+	}
+	return nil
+}
@@ -0,0 +1,379 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/crosse/msgraph/edm"
+	"golang.org/x/net/context"
+)
+
+// PasswordCredential is a client secret of an application or service
+// principal.
+type PasswordCredential struct {
+	// A base64-encoded identifier chosen by whoever added the secret.
+	CustomKeyIdentifier string `json:"customKeyIdentifier,omitempty"`
+
+	// A name for the secret.
+	DisplayName string `json:"displayName,omitempty"`
+
+	// The date and time at which the secret expires, in UTC.
+	EndDateTime *edm.DateTimeOffset `json:"endDateTime,omitempty"`
+
+	// The first characters of the secret. Read-only.
+	Hint string `json:"hint,omitempty"`
+
+	// The unique identifier of the secret.
+	KeyID string `json:"keyId,omitempty"`
+
+	// The secret itself. Graph only returns it when the secret is
+	// added. Read-only.
+	SecretText string `json:"secretText,omitempty"`
+
+	// The date and time at which the secret becomes valid, in UTC.
+	StartDateTime *edm.DateTimeOffset `json:"startDateTime,omitempty"`
+}
+
+// KeyCredential is a certificate of an application or service
+// principal.
+type KeyCredential struct {
+	// A base64-encoded identifier of the certificate, usually its
+	// thumbprint.
+	CustomKeyIdentifier string `json:"customKeyIdentifier,omitempty"`
+
+	// A name for the certificate.
+	DisplayName string `json:"displayName,omitempty"`
+
+	// The date and time at which the certificate expires, in UTC.
+	EndDateTime *edm.DateTimeOffset `json:"endDateTime,omitempty"`
+
+	// The base64-encoded certificate. Graph only returns it when it
+	// is selected explicitly.
+	Key string `json:"key,omitempty"`
+
+	// The unique identifier of the certificate.
+	KeyID string `json:"keyId,omitempty"`
+
+	// The date and time at which the certificate becomes valid, in
+	// UTC.
+	StartDateTime *edm.DateTimeOffset `json:"startDateTime,omitempty"`
+
+	// The type of the certificate, "AsymmetricX509Cert" or
+	// "X509CertAndPassword".
+	Type string `json:"type,omitempty"`
+
+	// What the certificate is used for, "Verify" or "Sign".
+	Usage string `json:"usage,omitempty"`
+}
+
+// AddApplicationPassword adds a client secret to the application with
+// the given object ID and returns it, including its SecretText, which
+// cannot be retrieved later. Only cred's DisplayName, StartDateTime and
+// EndDateTime are used; Graph chooses the secret and defaults to a
+// validity of two years.
+func (api *GraphAPI) AddApplicationPassword(ctx context.Context, id string, cred PasswordCredential) (PasswordCredential, error) {
+	return api.addPassword(ctx, "AddApplicationPassword", applicationResource, id, cred)
+}
+
+// RemoveApplicationPassword removes the client secret with the given
+// key ID from the application with the given object ID.
+func (api *GraphAPI) RemoveApplicationPassword(ctx context.Context, id, keyID string) error {
+	return api.removeCredential(ctx, "RemoveApplicationPassword", applicationResource, "removePassword", id, keyID, "")
+}
+
+// AddApplicationKey adds a certificate to the application with the
+// given object ID and returns it. proof is a JWT signed with one of the
+// application's existing certificates, as described for the addKey
+// action; an application without a valid certificate gets its first
+// one by updating keyCredentials instead.
+func (api *GraphAPI) AddApplicationKey(ctx context.Context, id string, key KeyCredential, proof string) (KeyCredential, error) {
+	return api.addKey(ctx, "AddApplicationKey", applicationResource, id, key, proof)
+}
+
+// RemoveApplicationKey removes the certificate with the given key ID
+// from the application with the given object ID. proof is a JWT signed
+// with one of the application's existing certificates.
+func (api *GraphAPI) RemoveApplicationKey(ctx context.Context, id, keyID, proof string) error {
+	return api.removeCredential(ctx, "RemoveApplicationKey", applicationResource, "removeKey", id, keyID, proof)
+}
+
+// AddServicePrincipalPassword is like AddApplicationPassword for a
+// service principal.
+func (api *GraphAPI) AddServicePrincipalPassword(ctx context.Context, id string, cred PasswordCredential) (PasswordCredential, error) {
+	return api.addPassword(ctx, "AddServicePrincipalPassword", servicePrincipalResource, id, cred)
+}
+
+// RemoveServicePrincipalPassword is like RemoveApplicationPassword for
+// a service principal.
+func (api *GraphAPI) RemoveServicePrincipalPassword(ctx context.Context, id, keyID string) error {
+	return api.removeCredential(ctx, "RemoveServicePrincipalPassword", servicePrincipalResource, "removePassword", id, keyID, "")
+}
+
+// AddServicePrincipalKey is like AddApplicationKey for a service
+// principal.
+func (api *GraphAPI) AddServicePrincipalKey(ctx context.Context, id string, key KeyCredential, proof string) (KeyCredential, error) {
+	return api.addKey(ctx, "AddServicePrincipalKey", servicePrincipalResource, id, key, proof)
+}
+
+// RemoveServicePrincipalKey is like RemoveApplicationKey for a service
+// principal.
+func (api *GraphAPI) RemoveServicePrincipalKey(ctx context.Context, id, keyID, proof string) error {
+	return api.removeCredential(ctx, "RemoveServicePrincipalKey", servicePrincipalResource, "removeKey", id, keyID, proof)
+}
+
+func (api *GraphAPI) addPassword(ctx context.Context, operation string, r Resource, id string, cred PasswordCredential) (created PasswordCredential, err error) {
+	ctx, span := api.startOperation(ctx, operation, r)
+	defer func() { endOperation(span, err) }()
+
+	log.WithFields(log.Fields{
+		"resource": r.Name,
+		"id":       id,
+		"name":     cred.DisplayName,
+	}).Info("Adding password credential in Graph API")
+
+	endpoint, err := api.Endpoint(ctx, r, "addPassword", id)
+	if err != nil {
+		return
+	}
+	data, err := withoutNulls(PasswordCredential{
+		DisplayName:   cred.DisplayName,
+		StartDateTime: cred.StartDateTime,
+		EndDateTime:   cred.EndDateTime,
+	})
+	if err != nil {
+		return
+	}
+
+	body := map[string]json.RawMessage{"passwordCredential": data}
+	err = api.send(ctx, r, "POST", endpoint, body, &created)
+	return
+}
+
+func (api *GraphAPI) addKey(ctx context.Context, operation string, r Resource, id string, key KeyCredential, proof string) (created KeyCredential, err error) {
+	ctx, span := api.startOperation(ctx, operation, r)
+	defer func() { endOperation(span, err) }()
+
+	if proof == "" {
+		return created, &ValidationError{[]*FieldError{{"proof", "is required"}}}
+	}
+
+	log.WithFields(log.Fields{
+		"resource": r.Name,
+		"id":       id,
+		"name":     key.DisplayName,
+	}).Info("Adding key credential in Graph API")
+
+	endpoint, err := api.Endpoint(ctx, r, "addKey", id)
+	if err != nil {
+		return
+	}
+	data, err := withoutNulls(key)
+	if err != nil {
+		return
+	}
+
+	body := map[string]interface{}{
+		"keyCredential":      data,
+		"passwordCredential": nil,
+		"proof":              proof,
+	}
+	err = api.send(ctx, r, "POST", endpoint, body, &created)
+	return
+}
+
+// removeCredential removes the secret or certificate with the given key
+// ID through route. proof is only sent if it is not empty.
+func (api *GraphAPI) removeCredential(ctx context.Context, operation string, r Resource, route, id, keyID, proof string) (err error) {
+	ctx, span := api.startOperation(ctx, operation, r)
+	defer func() { endOperation(span, err) }()
+
+	log.WithFields(log.Fields{
+		"resource": r.Name,
+		"id":       id,
+		"keyId":    keyID,
+	}).Info("Removing credential in Graph API")
+
+	endpoint, err := api.Endpoint(ctx, r, route, id)
+	if err != nil {
+		return
+	}
+
+	body := map[string]string{"keyId": keyID}
+	if proof != "" {
+		body["proof"] = proof
+	}
+	return api.send(ctx, r, "POST", endpoint, body, nil)
+}
+
+// Values of Credential.Kind.
+const (
+	CredentialKindPassword    = "password"
+	CredentialKindCertificate = "certificate"
+)
+
+// Credential describes a secret or certificate of an application or
+// service principal, as returned by ListCredentials.
+type Credential struct {
+	// OwnerType is ODataTypeApplication or ODataTypeServicePrincipal.
+	OwnerType string
+
+	// OwnerID is the object ID of the application or service
+	// principal.
+	OwnerID string
+
+	// OwnerName is the display name of the application or service
+	// principal.
+	OwnerName string
+
+	// AppID is the application (client) ID of the owner.
+	AppID string
+
+	// Kind is CredentialKindPassword or CredentialKindCertificate.
+	Kind string
+
+	// KeyID is the unique identifier of the credential.
+	KeyID string
+
+	// DisplayName is the name of the credential.
+	DisplayName string
+
+	// Hint holds the first characters of a secret.
+	Hint string
+
+	// StartDateTime and EndDateTime are when the credential becomes
+	// valid and when it expires.
+	StartDateTime edm.DateTimeOffset
+	EndDateTime   edm.DateTimeOffset
+}
+
+// ExpiresBefore reports whether the credential expires before t. A
+// credential without an expiry date never expires.
+func (c Credential) ExpiresBefore(t time.Time) bool {
+	return c.EndDateTime.Valid && c.EndDateTime.Time.Before(t)
+}
+
+// credentialProperties are the properties ListCredentials selects.
+var credentialProperties = []string{"id", "appId", "displayName", "passwordCredentials", "keyCredentials"}
+
+// ListCredentials returns the secrets and certificates of all
+// applications and service principals in the tenant, sorted by their
+// expiry dates.
+func (api *GraphAPI) ListCredentials(ctx context.Context) ([]Credential, error) {
+	var creds []Credential
+
+	apps := api.ListApplications(ctx, &Query{Select: credentialProperties, Top: 999})
+	defer apps.Close()
+	for apps.Next() {
+		app := apps.Application()
+		creds = append(creds, app.Credentials()...)
+	}
+	if err := apps.Err(); err != nil {
+		return nil, err
+	}
+
+	sps := api.ListServicePrincipals(ctx, &Query{Select: credentialProperties, Top: 999})
+	defer sps.Close()
+	for sps.Next() {
+		sp := sps.ServicePrincipal()
+		creds = append(creds, sp.Credentials()...)
+	}
+	if err := sps.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(creds, func(i, j int) bool {
+		return expiresFirst(creds[i].EndDateTime, creds[j].EndDateTime)
+	})
+	return creds, nil
+}
+
+// Credentials returns the secrets and certificates of the application.
+func (a *Application) Credentials() []Credential {
+	return appendCredentials(nil, ODataTypeApplication, a.ID, a.DisplayName, a.AppID, a.PasswordCredentials, a.KeyCredentials)
+}
+
+// Credentials returns the secrets and certificates of the service
+// principal.
+func (sp *ServicePrincipal) Credentials() []Credential {
+	return appendCredentials(nil, ODataTypeServicePrincipal, sp.ID, sp.DisplayName, sp.AppID, sp.PasswordCredentials, sp.KeyCredentials)
+}
+
+func appendCredentials(creds []Credential, typ, id, name, appID string, passwords []PasswordCredential, keys []KeyCredential) []Credential {
+	for _, p := range passwords {
+		creds = append(creds, Credential{
+			OwnerType:     typ,
+			OwnerID:       id,
+			OwnerName:     name,
+			AppID:         appID,
+			Kind:          CredentialKindPassword,
+			KeyID:         p.KeyID,
+			DisplayName:   p.DisplayName,
+			Hint:          p.Hint,
+			StartDateTime: dateValue(p.StartDateTime),
+			EndDateTime:   dateValue(p.EndDateTime),
+		})
+	}
+	for _, k := range keys {
+		creds = append(creds, Credential{
+			OwnerType:     typ,
+			OwnerID:       id,
+			OwnerName:     name,
+			AppID:         appID,
+			Kind:          CredentialKindCertificate,
+			KeyID:         k.KeyID,
+			DisplayName:   k.DisplayName,
+			StartDateTime: dateValue(k.StartDateTime),
+			EndDateTime:   dateValue(k.EndDateTime),
+		})
+	}
+	return creds
+}
+
+// CurrentClientSecret returns the credential of the client secret the
+// GraphAPI authenticates with, so that its expiry can be checked before
+// sign-ins start to fail. Graph only keeps the first characters of each
+// secret; if several secrets of the application start the same way, the
+// one that expires first is returned. The application needs permission
+// to read its own registration.
+func (api *GraphAPI) CurrentClientSecret(ctx context.Context) (PasswordCredential, error) {
+	app, err := api.GetApplicationByAppID(ctx, api.config.ClientID, []string{"id", "passwordCredentials"})
+	if err != nil {
+		return PasswordCredential{}, err
+	}
+
+	var found *PasswordCredential
+	for i, p := range app.PasswordCredentials {
+		if p.Hint == "" || !strings.HasPrefix(api.config.ClientSecret, p.Hint) {
+			continue
+		}
+		if found == nil || expiresFirst(dateValue(p.EndDateTime), dateValue(found.EndDateTime)) {
+			found = &app.PasswordCredentials[i]
+		}
+	}
+	if found == nil {
+		return PasswordCredential{}, &GraphAPIError{fmt.Sprintf("No secret of application %v matches the client secret", api.config.ClientID), nil}
+	}
+	return *found, nil
+}
+
+// dateValue returns the value d points to, or an invalid value if d is
+// nil.
+func dateValue(d *edm.DateTimeOffset) edm.DateTimeOffset {
+	if d == nil {
+		return edm.DateTimeOffset{}
+	}
+	return *d
+}
+
+// expiresFirst reports whether expiry date a comes before b. A missing
+// date never expires.
+func expiresFirst(a, b edm.DateTimeOffset) bool {
+	if a.Valid != b.Valid {
+		return a.Valid
+	}
+	return a.Valid && a.Time.Before(b.Time)
+}
@@ -0,0 +1,14 @@
+package msgraph
+
+// deletedItemResource holds directory objects that were deleted within
+// the last 30 days and can still be restored.
+var deletedItemResource = RegisterResource(Resource{
+	Name:       "DeletedItem",
+	APIVersion: APIVersionV1,
+	Resource:   "directory/deletedItems",
+	Routes: map[string]Route{
+		"item":    "directory/deletedItems/{id}",
+		"restore": "directory/deletedItems/{id}/restore",
+		"users":   "directory/deletedItems/microsoft.graph.user",
+	},
+})
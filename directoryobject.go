@@ -0,0 +1,236 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// directoryObjectResource refers to directory objects of any type by
+// their IDs.
+var directoryObjectResource = RegisterResource(Resource{
+	Name:       "DirectoryObject",
+	APIVersion: APIVersionV1,
+	Resource:   "directoryObjects",
+	Routes: map[string]Route{
+		"item": "directoryObjects/{id}",
+	},
+})
+
+// DirectoryObject is a typed object from the directory, as returned by
+// collections that can hold objects of several types, such as the
+// groups, directory roles and administrative units a user is a member
+// of. Use a type switch to get at the concrete type.
+type DirectoryObject interface {
+	// ObjectID returns the unique identifier of the object.
+	ObjectID() string
+
+	// ObjectType returns the OData type of the object, for example
+	// "#microsoft.graph.group".
+	ObjectType() string
+}
+
+// OData types of the directory objects this package knows about.
+const (
+	ODataTypeUser               = "#microsoft.graph.user"
+	ODataTypeGroup              = "#microsoft.graph.group"
+	ODataTypeDirectoryRole      = "#microsoft.graph.directoryRole"
+	ODataTypeAdministrativeUnit = "#microsoft.graph.administrativeUnit"
+	ODataTypeApplication        = "#microsoft.graph.application"
+	ODataTypeServicePrincipal   = "#microsoft.graph.servicePrincipal"
+)
+
+// directoryObjectTypes creates an empty object for each known OData
+// type.
+var directoryObjectTypes = map[string]func() DirectoryObject{
+	ODataTypeUser:               func() DirectoryObject { return &User{} },
+	ODataTypeGroup:              func() DirectoryObject { return &Group{} },
+	ODataTypeDirectoryRole:      func() DirectoryObject { return &DirectoryRole{} },
+	ODataTypeAdministrativeUnit: func() DirectoryObject { return &AdministrativeUnit{} },
+	ODataTypeApplication:        func() DirectoryObject { return &Application{} },
+	ODataTypeServicePrincipal:   func() DirectoryObject { return &ServicePrincipal{} },
+}
+
+// ObjectID implements DirectoryObject.
+func (u *User) ObjectID() string { return u.ID }
+
+// ObjectType implements DirectoryObject.
+func (u *User) ObjectType() string { return ODataTypeUser }
+
+// DirectoryRole is a directory role that is activated in the tenant.
+type DirectoryRole struct {
+	// The unique identifier for the directory role. Read-only.
+	ID string `json:"id,omitempty"`
+
+	// The description of the directory role. Read-only.
+	Description string `json:"description,omitempty"`
+
+	// The display name of the directory role. Read-only.
+	DisplayName string `json:"displayName,omitempty"`
+
+	// The ID of the directory role template the role is based on.
+	RoleTemplateID string `json:"roleTemplateId,omitempty"`
+
+	// AdditionalData holds the properties and annotations returned by
+	// Graph that DirectoryRole has no field for, keyed by their names.
+	AdditionalData map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler. Additional data is encoded
+// alongside the built-in properties.
+func (r DirectoryRole) MarshalJSON() ([]byte, error) {
+	type directoryRole DirectoryRole
+	return marshalWithAdditional(directoryRole(r), r.AdditionalData)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *DirectoryRole) UnmarshalJSON(data []byte) error {
+	type directoryRole DirectoryRole
+	if err := json.Unmarshal(data, (*directoryRole)(r)); err != nil {
+		return err
+	}
+	unknown, err := unknownProperties(data, reflect.TypeOf(*r))
+	r.AdditionalData = unknown
+	return err
+}
+
+// ObjectID implements DirectoryObject.
+func (r *DirectoryRole) ObjectID() string { return r.ID }
+
+// ObjectType implements DirectoryObject.
+func (r *DirectoryRole) ObjectType() string { return ODataTypeDirectoryRole }
+
+// AdministrativeUnit is a container of directory objects that can be
+// administered separately.
+type AdministrativeUnit struct {
+	// The unique identifier for the administrative unit. Read-only.
+	ID string `json:"id,omitempty"`
+
+	// An optional description for the administrative unit.
+	Description string `json:"description,omitempty"`
+
+	// The display name for the administrative unit.
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Controls whether the administrative unit and its members are
+	// hidden or public. When not set, the unit is public.
+	Visibility string `json:"visibility,omitempty"`
+
+	// AdditionalData holds the properties and annotations returned by
+	// Graph that AdministrativeUnit has no field for, keyed by their names.
+	AdditionalData map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler. Additional data is encoded
+// alongside the built-in properties.
+func (a AdministrativeUnit) MarshalJSON() ([]byte, error) {
+	type administrativeUnit AdministrativeUnit
+	return marshalWithAdditional(administrativeUnit(a), a.AdditionalData)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (a *AdministrativeUnit) UnmarshalJSON(data []byte) error {
+	type administrativeUnit AdministrativeUnit
+	if err := json.Unmarshal(data, (*administrativeUnit)(a)); err != nil {
+		return err
+	}
+	unknown, err := unknownProperties(data, reflect.TypeOf(*a))
+	a.AdditionalData = unknown
+	return err
+}
+
+// ObjectID implements DirectoryObject.
+func (a *AdministrativeUnit) ObjectID() string { return a.ID }
+
+// ObjectType implements DirectoryObject.
+func (a *AdministrativeUnit) ObjectType() string { return ODataTypeAdministrativeUnit }
+
+// UnknownDirectoryObject is a directory object of a type this package
+// does not know about, such as a device or an organizational contact.
+type UnknownDirectoryObject struct {
+	// The unique identifier for the object.
+	ID string `json:"id"`
+
+	// The OData type of the object.
+	Type string `json:"@odata.type"`
+
+	// The properties of the object as returned by Graph.
+	Raw json.RawMessage `json:"-"`
+}
+
+// ObjectID implements DirectoryObject.
+func (o *UnknownDirectoryObject) ObjectID() string { return o.ID }
+
+// ObjectType implements DirectoryObject.
+func (o *UnknownDirectoryObject) ObjectType() string { return o.Type }
+
+// decodeDirectoryObject decodes a directory object into the type named
+// by its @odata.type annotation.
+func decodeDirectoryObject(data json.RawMessage) (DirectoryObject, error) {
+	var probe struct {
+		Type string `json:"@odata.type"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, &GraphAPIError{fmt.Sprintf("Decoding directory object: %v", err), err}
+	}
+
+	newObject, ok := directoryObjectTypes[probe.Type]
+	if !ok {
+		o := &UnknownDirectoryObject{Raw: data}
+		if err := json.Unmarshal(data, o); err != nil {
+			return nil, &GraphAPIError{fmt.Sprintf("Decoding directory object: %v", err), err}
+		}
+		return o, nil
+	}
+
+	o := newObject()
+	if err := json.Unmarshal(data, o); err != nil {
+		return nil, &GraphAPIError{fmt.Sprintf("Decoding %v: %v", probe.Type, err), err}
+	}
+	return o, nil
+}
+
+// DirectoryObjectIterator iterates over a collection of directory
+// objects of mixed types.
+type DirectoryObjectIterator struct {
+	c   *Collection
+	obj DirectoryObject
+}
+
+// Next advances the iterator to the next object, which can then be read
+// with Object. It returns false when there are no more objects or an
+// error occurred; Err tells the two apart.
+func (it *DirectoryObjectIterator) Next() bool {
+	if !it.c.Next() {
+		return false
+	}
+
+	var data json.RawMessage
+	if err := it.c.Decode(&data); err != nil {
+		it.c.Close()
+		return false
+	}
+	obj, err := decodeDirectoryObject(data)
+	if err != nil {
+		it.c.err = err
+		it.c.Close()
+		return false
+	}
+	it.obj = obj
+	return true
+}
+
+// Object returns the current object.
+func (it *DirectoryObjectIterator) Object() DirectoryObject {
+	return it.obj
+}
+
+// Err returns the first error encountered while iterating.
+func (it *DirectoryObjectIterator) Err() error {
+	return it.c.Err()
+}
+
+// Close stops the iteration early.
+func (it *DirectoryObjectIterator) Close() error {
+	return it.c.Close()
+}
@@ -0,0 +1,74 @@
+package edm
+
+import (
+	"fmt"
+	"time"
+)
+
+// Date is an Edm.Date: a calendar date without a time or time zone, in
+// the form "2006-01-02".
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+	Valid bool
+}
+
+// NewDate returns the valid Date for the given year, month and day.
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{Year: year, Month: month, Day: day, Valid: true}
+}
+
+// DateOf returns the date of t in t's location.
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return NewDate(y, m, d)
+}
+
+// ParseDate parses a date of the form "2006-01-02". For leniency, a
+// date and time such as "2006-01-02T00:00:00Z" is accepted as well; its
+// date is taken as written, without converting it to another time
+// zone.
+func ParseDate(s string) (Date, error) {
+	if len(s) > 10 && s[10] == 'T' {
+		s = s[:10]
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return Date{}, fmt.Errorf("edm: invalid Edm.Date %q", s)
+	}
+	return DateOf(t), nil
+}
+
+// Time returns midnight at the start of the date in loc.
+func (d Date) Time(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// String returns the date in the form "2006-01-02", or "" if d is not
+// valid.
+func (d Date) String() string {
+	if !d.Valid {
+		return ""
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Date) MarshalJSON() ([]byte, error) {
+	return quote(d.String(), d.Valid)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if isNull(data) {
+		*d = Date{}
+		return nil
+	}
+	s, err := unquote(data, "Edm.Date")
+	if err != nil {
+		return err
+	}
+	*d, err = ParseDate(s)
+	return err
+}
@@ -0,0 +1,109 @@
+package edm
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateTimeOffset is an Edm.DateTimeOffset: a point in time together
+// with the UTC offset it was written in.
+//
+// A DateTimeOffset decoded from JSON remembers its original text and
+// encodes it unchanged as long as Time is not changed, so that values
+// survive a round trip byte for byte.
+type DateTimeOffset struct {
+	Time  time.Time
+	Valid bool
+
+	raw string
+}
+
+// NewDateTimeOffset returns the valid DateTimeOffset for t.
+func NewDateTimeOffset(t time.Time) DateTimeOffset {
+	return DateTimeOffset{Time: t, Valid: true}
+}
+
+// dateTimeOffsetLayouts are the forms ParseDateTimeOffset accepts, in
+// the order they are tried. Fractional seconds of any precision are
+// accepted by each of them.
+var dateTimeOffsetLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// ParseDateTimeOffset parses a date and time in RFC 3339 form, such as
+// "2006-01-02T15:04:05.0000000Z" or "2006-01-02T15:04:05+01:00". For
+// leniency it also accepts offsets without a colon, values without an
+// offset or seconds, which are taken to be in UTC, and date-only values,
+// which are taken to be midnight UTC.
+func ParseDateTimeOffset(s string) (DateTimeOffset, error) {
+	v := strings.TrimSpace(s)
+	if strings.HasSuffix(v, "z") {
+		v = v[:len(v)-1] + "Z"
+	}
+	for _, layout := range dateTimeOffsetLayouts {
+		if t, err := time.Parse(layout, v); err == nil {
+			return DateTimeOffset{Time: t, Valid: true, raw: s}, nil
+		}
+	}
+	return DateTimeOffset{}, fmt.Errorf("edm: invalid Edm.DateTimeOffset %q", s)
+}
+
+// Date returns the date of the value as written, without converting it
+// to another time zone.
+func (d DateTimeOffset) Date() Date {
+	if !d.Valid {
+		return Date{}
+	}
+	return DateOf(d.Time)
+}
+
+// unchanged reports whether Time still is the time the value was
+// decoded from.
+func (d DateTimeOffset) unchanged() bool {
+	if d.raw == "" {
+		return false
+	}
+	orig, err := ParseDateTimeOffset(d.raw)
+	if err != nil || !orig.Time.Equal(d.Time) {
+		return false
+	}
+	_, o1 := orig.Time.Zone()
+	_, o2 := d.Time.Zone()
+	return o1 == o2
+}
+
+// String returns the value in RFC 3339 form, or "" if d is not valid.
+func (d DateTimeOffset) String() string {
+	switch {
+	case !d.Valid:
+		return ""
+	case d.unchanged():
+		return d.raw
+	}
+	return d.Time.Format(time.RFC3339Nano)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d DateTimeOffset) MarshalJSON() ([]byte, error) {
+	return quote(d.String(), d.Valid)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *DateTimeOffset) UnmarshalJSON(data []byte) error {
+	if isNull(data) {
+		*d = DateTimeOffset{}
+		return nil
+	}
+	s, err := unquote(data, "Edm.DateTimeOffset")
+	if err != nil {
+		return err
+	}
+	*d, err = ParseDateTimeOffset(s)
+	return err
+}
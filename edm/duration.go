@@ -0,0 +1,189 @@
+package edm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Duration is an Edm.Duration: a signed length of time in ISO 8601
+// form, such as "P1DT2H30M" or "-PT0.5S". Only days, hours, minutes
+// and seconds are allowed, as months and years have no fixed length.
+//
+// A Duration decoded from JSON remembers its original text and encodes
+// it unchanged as long as Duration is not changed, so that "PT36H" does
+// not come back as "P1DT12H".
+type Duration struct {
+	Duration time.Duration
+	Valid    bool
+
+	raw string
+}
+
+// NewDuration returns the valid Duration for d.
+func NewDuration(d time.Duration) Duration {
+	return Duration{Duration: d, Valid: true}
+}
+
+// ParseDuration parses a duration of the form "[-]P[nD][T[nH][nM][n[.n]S]]".
+// Fractions of a second are kept to the nanosecond.
+func ParseDuration(s string) (Duration, error) {
+	invalid := fmt.Errorf("edm: invalid Edm.Duration %q", s)
+
+	v := s
+	neg := strings.HasPrefix(v, "-")
+	if neg {
+		v = v[1:]
+	}
+	if !strings.HasPrefix(v, "P") || len(v) < 3 {
+		return Duration{}, invalid
+	}
+	v = v[1:]
+
+	var d time.Duration
+	inTime := false
+	for v != "" {
+		if v[0] == 'T' {
+			if inTime || len(v) == 1 {
+				return Duration{}, invalid
+			}
+			inTime = true
+			v = v[1:]
+			continue
+		}
+
+		i := strings.IndexAny(v, "DHMS")
+		if i <= 0 {
+			return Duration{}, invalid
+		}
+		num, unit := v[:i], v[i]
+		v = v[i+1:]
+
+		if unit == 'S' {
+			if !inTime {
+				return Duration{}, invalid
+			}
+			secs, frac := num, ""
+			if j := strings.IndexByte(num, '.'); j >= 0 {
+				secs, frac = num[:j], num[j+1:]
+			}
+			n, err := strconv.ParseInt(secs, 10, 64)
+			if err != nil || n < 0 {
+				return Duration{}, invalid
+			}
+			d += time.Duration(n) * time.Second
+			if frac != "" {
+				if len(frac) > 9 {
+					frac = frac[:9]
+				}
+				f, err := strconv.ParseInt(frac+strings.Repeat("0", 9-len(frac)), 10, 64)
+				if err != nil || f < 0 {
+					return Duration{}, invalid
+				}
+				d += time.Duration(f)
+			}
+			continue
+		}
+
+		n, err := strconv.ParseInt(num, 10, 64)
+		if err != nil || n < 0 {
+			return Duration{}, invalid
+		}
+		switch {
+		case unit == 'D' && !inTime:
+			d += time.Duration(n) * 24 * time.Hour
+		case unit == 'H' && inTime:
+			d += time.Duration(n) * time.Hour
+		case unit == 'M' && inTime:
+			d += time.Duration(n) * time.Minute
+		default:
+			return Duration{}, invalid
+		}
+	}
+
+	if neg {
+		d = -d
+	}
+	return Duration{Duration: d, Valid: true, raw: s}, nil
+}
+
+// unchanged reports whether Duration still is the duration the value
+// was decoded from.
+func (d Duration) unchanged() bool {
+	if d.raw == "" {
+		return false
+	}
+	orig, err := ParseDuration(d.raw)
+	return err == nil && orig.Duration == d.Duration
+}
+
+// String returns the duration in ISO 8601 form, such as "P1DT2H30M",
+// or "" if d is not valid.
+func (d Duration) String() string {
+	switch {
+	case !d.Valid:
+		return ""
+	case d.unchanged():
+		return d.raw
+	}
+
+	var b strings.Builder
+	v := d.Duration
+	if v < 0 {
+		b.WriteByte('-')
+		v = -v
+	}
+	b.WriteByte('P')
+
+	days := v / (24 * time.Hour)
+	v -= days * 24 * time.Hour
+	if days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+	}
+	if v == 0 && days > 0 {
+		return b.String()
+	}
+
+	b.WriteByte('T')
+	h := v / time.Hour
+	v -= h * time.Hour
+	m := v / time.Minute
+	v -= m * time.Minute
+	if h > 0 {
+		fmt.Fprintf(&b, "%dH", h)
+	}
+	if m > 0 {
+		fmt.Fprintf(&b, "%dM", m)
+	}
+	if v > 0 || (h == 0 && m == 0) {
+		secs := v / time.Second
+		b.WriteString(strconv.FormatInt(int64(secs), 10))
+		if ns := v - secs*time.Second; ns > 0 {
+			frac := fmt.Sprintf("%09d", ns)
+			b.WriteByte('.')
+			b.WriteString(strings.TrimRight(frac, "0"))
+		}
+		b.WriteByte('S')
+	}
+	return b.String()
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return quote(d.String(), d.Valid)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	if isNull(data) {
+		*d = Duration{}
+		return nil
+	}
+	s, err := unquote(data, "Edm.Duration")
+	if err != nil {
+		return err
+	}
+	*d, err = ParseDuration(s)
+	return err
+}
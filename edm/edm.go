@@ -0,0 +1,35 @@
+// Package edm implements the primitive types of the OData Entity Data
+// Model (EDM) that have no exact Go equivalent: Edm.Date,
+// Edm.DateTimeOffset, Edm.Duration, Edm.TimeOfDay and Edm.Guid.
+//
+// Each type encodes to and decodes from the JSON form Graph uses. Like
+// the types of gopkg.in/guregu/null, each has a Valid field; an invalid
+// value encodes as JSON null, and JSON null decodes to an invalid value.
+package edm
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// isNull reports whether data is the JSON null.
+func isNull(data []byte) bool {
+	return string(data) == "null"
+}
+
+// unquote decodes the JSON string in data.
+func unquote(data []byte, typ string) (string, error) {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return "", fmt.Errorf("edm: cannot decode %s into %s", data, typ)
+	}
+	return s, nil
+}
+
+// quote encodes s as a JSON string, or null if valid is false.
+func quote(s string, valid bool) ([]byte, error) {
+	if !valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(s)
+}
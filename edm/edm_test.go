@@ -0,0 +1,252 @@
+package edm
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Date
+		ok   bool
+	}{
+		{"1990-05-01", NewDate(1990, time.May, 1), true},
+		{"2020-02-29", NewDate(2020, time.February, 29), true},
+		// Dates and times keep the date as written.
+		{"2006-01-02T23:00:00-05:00", NewDate(2006, time.January, 2), true},
+		{"2020-02-30", Date{}, false},
+		{"2020-1-1", Date{}, false},
+		{"", Date{}, false},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDate(tt.in)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("ParseDate(%q) = %v, %v; want %v, ok %v", tt.in, got, err, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseDateTimeOffset(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Time
+		ok   bool
+	}{
+		{"2014-01-01T00:00:00.0000000Z", time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		{"2020-01-01T10:00:00+05:30", time.Date(2020, 1, 1, 4, 30, 0, 0, time.UTC), true},
+		{"2020-01-01T10:00:00+0530", time.Date(2020, 1, 1, 4, 30, 0, 0, time.UTC), true},
+		{"2020-01-01T10:00:00.5z", time.Date(2020, 1, 1, 10, 0, 0, 5e8, time.UTC), true},
+		// Values without an offset are in UTC.
+		{"2020-01-01T10:00:00", time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC), true},
+		{"2020-01-01T10:00", time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC), true},
+		{"2020-01-01", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		{"2020-13-01T00:00:00Z", time.Time{}, false},
+		{"yesterday", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDateTimeOffset(tt.in)
+		if (err == nil) != tt.ok || !got.Time.Equal(tt.want) || got.Valid != tt.ok {
+			t.Errorf("ParseDateTimeOffset(%q) = %v, %v; want %v, ok %v", tt.in, got.Time, err, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+		ok   bool
+	}{
+		{"P1DT2H30M", 26*time.Hour + 30*time.Minute, true},
+		{"-PT0.5S", -500 * time.Millisecond, true},
+		{"PT0S", 0, true},
+		{"P2D", 48 * time.Hour, true},
+		{"PT36H", 36 * time.Hour, true},
+		{"P1DT1.000000001S", 24*time.Hour + time.Second + 1, true},
+		// Fractions beyond nanoseconds are cut off.
+		{"PT0.0000000019S", 1, true},
+		{"P1Y", 0, false},
+		{"P1M", 0, false},
+		{"P1H", 0, false},
+		{"PT1D", 0, false},
+		{"PT1.5M", 0, false},
+		{"P-1D", 0, false},
+		{"PT", 0, false},
+		{"P", 0, false},
+		{"1D", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.in)
+		if (err == nil) != tt.ok || got.Duration != tt.want || got.Valid != tt.ok {
+			t.Errorf("ParseDuration(%q) = %v, %v; want %v, ok %v", tt.in, got.Duration, err, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseTimeOfDay(t *testing.T) {
+	tests := []struct {
+		in   string
+		want TimeOfDay
+		ok   bool
+	}{
+		{"13:05:00", NewTimeOfDay(13, 5, 0, 0), true},
+		{"13:05:00.1230000", NewTimeOfDay(13, 5, 0, 123000000), true},
+		{"00:00", NewTimeOfDay(0, 0, 0, 0), true},
+		{"24:00:00", TimeOfDay{}, false},
+		{"13:60:00", TimeOfDay{}, false},
+		{"noon", TimeOfDay{}, false},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTimeOfDay(tt.in)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("ParseTimeOfDay(%q) = %v, %v; want %v, ok %v", tt.in, got, err, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseGuid(t *testing.T) {
+	const want = "6fd2c87f-b296-42f0-b197-1e91e994b900"
+	tests := []struct {
+		in string
+		ok bool
+	}{
+		{"6fd2c87f-b296-42f0-b197-1e91e994b900", true},
+		{"6FD2C87F-B296-42F0-B197-1E91E994B900", true},
+		{"{6FD2C87F-B296-42F0-B197-1E91E994B900}", true},
+		{"6fd2c87fb29642f0b1971e91e994b900", false},
+		{"6fd2c87f-b296-42f0-b197-1e91e994b9", false},
+		{"6fd2c87f-b296-42f0-b197-1e91e994b9zz", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseGuid(tt.in)
+		switch {
+		case (err == nil) != tt.ok:
+			t.Errorf("ParseGuid(%q) error = %v, want ok %v", tt.in, err, tt.ok)
+		case tt.ok && got.String() != want:
+			t.Errorf("ParseGuid(%q) = %v, want %v", tt.in, got, want)
+		}
+	}
+}
+
+// TestJSON decodes each value and encodes it again. Values whose text
+// is kept come back unchanged; the others come back in canonical form.
+func TestJSON(t *testing.T) {
+	tests := []struct {
+		v    interface{}
+		in   string
+		want string
+	}{
+		{new(Date), `"1990-05-01"`, `"1990-05-01"`},
+		{new(Date), `"1990-05-01T00:00:00Z"`, `"1990-05-01"`},
+		{new(DateTimeOffset), `"2014-01-01T00:00:00.0000000Z"`, `"2014-01-01T00:00:00.0000000Z"`},
+		{new(DateTimeOffset), `"2020-01-01T10:00:00+0530"`, `"2020-01-01T10:00:00+0530"`},
+		{new(DateTimeOffset), `"2020-01-01"`, `"2020-01-01"`},
+		{new(Duration), `"PT36H"`, `"PT36H"`},
+		{new(Duration), `"P0DT0H1M30.000S"`, `"P0DT0H1M30.000S"`},
+		{new(Duration), `"-PT0.5S"`, `"-PT0.5S"`},
+		{new(TimeOfDay), `"13:05:00.1230000"`, `"13:05:00.123"`},
+		{new(Guid), `"6FD2C87F-B296-42F0-B197-1E91E994B900"`, `"6fd2c87f-b296-42f0-b197-1e91e994b900"`},
+
+		// null decodes to an invalid value, which encodes as null.
+		{new(Date), `null`, `null`},
+		{new(DateTimeOffset), `null`, `null`},
+		{new(Duration), `null`, `null`},
+		{new(TimeOfDay), `null`, `null`},
+		{new(Guid), `null`, `null`},
+	}
+
+	for _, tt := range tests {
+		if err := json.Unmarshal([]byte(tt.in), tt.v); err != nil {
+			t.Errorf("Unmarshal(%s) into %T: %v", tt.in, tt.v, err)
+			continue
+		}
+		got, err := json.Marshal(tt.v)
+		if err != nil {
+			t.Errorf("Marshal(%T from %s): %v", tt.v, tt.in, err)
+			continue
+		}
+		if string(got) != tt.want {
+			t.Errorf("%T: %s encodes as %s, want %s", tt.v, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestJSONInvalid(t *testing.T) {
+	for _, in := range []string{`"garbage"`, `42`, `true`, `{}`} {
+		for _, v := range []interface{}{new(Date), new(DateTimeOffset), new(Duration), new(TimeOfDay), new(Guid)} {
+			if err := json.Unmarshal([]byte(in), v); err == nil {
+				t.Errorf("Unmarshal(%s) into %T succeeded, want an error", in, v)
+			}
+		}
+	}
+}
+
+// TestJSONZero checks that the zero values are invalid and encode as
+// null, and that a null replaces a valid value.
+func TestJSONZero(t *testing.T) {
+	for _, v := range []interface{}{Date{}, DateTimeOffset{}, Duration{}, TimeOfDay{}, Guid{}} {
+		got, err := json.Marshal(v)
+		if err != nil || string(got) != "null" {
+			t.Errorf("Marshal(%T{}) = %s, %v; want null", v, got, err)
+		}
+	}
+
+	d := NewDuration(time.Hour)
+	if err := json.Unmarshal([]byte(`null`), &d); err != nil || d.Valid {
+		t.Errorf("Unmarshal(null) = %+v, %v; want an invalid Duration", d, err)
+	}
+}
+
+// TestJSONChanged checks that values changed after decoding are encoded
+// from their new value rather than from the original text.
+func TestJSONChanged(t *testing.T) {
+	var dt DateTimeOffset
+	if err := json.Unmarshal([]byte(`"2020-01-01T10:00:00+0530"`), &dt); err != nil {
+		t.Fatal(err)
+	}
+	dt.Time = dt.Time.Add(time.Hour)
+	if got := dt.String(); got != "2020-01-01T11:00:00+05:30" {
+		t.Errorf("changed DateTimeOffset = %v, want 2020-01-01T11:00:00+05:30", got)
+	}
+	dt.Time = dt.Time.Add(-time.Hour).UTC()
+	if got := dt.String(); got != "2020-01-01T04:30:00Z" {
+		t.Errorf("DateTimeOffset moved to UTC = %v, want 2020-01-01T04:30:00Z", got)
+	}
+
+	var d Duration
+	if err := json.Unmarshal([]byte(`"PT36H"`), &d); err != nil {
+		t.Fatal(err)
+	}
+	d.Duration += 90 * time.Second
+	if got := d.String(); got != "P1DT12H1M30S" {
+		t.Errorf("changed Duration = %v, want P1DT12H1M30S", got)
+	}
+}
+
+func TestDurationString(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "PT0S"},
+		{24 * time.Hour, "P1D"},
+		{36 * time.Hour, "P1DT12H"},
+		{90 * time.Second, "PT1M30S"},
+		{-500 * time.Millisecond, "-PT0.5S"},
+		{time.Hour + time.Nanosecond, "PT1H0.000000001S"},
+	}
+
+	for _, tt := range tests {
+		if got := NewDuration(tt.d).String(); got != tt.want {
+			t.Errorf("NewDuration(%v) = %v, want %v", tt.d, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package edm
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Guid is an Edm.Guid, in the form
+// "01234567-89ab-cdef-0123-456789abcdef".
+type Guid struct {
+	UUID  [16]byte
+	Valid bool
+}
+
+// ParseGuid parses a GUID in the usual 8-4-4-4-12 hexadecimal form,
+// with or without surrounding braces, in either case.
+func ParseGuid(s string) (Guid, error) {
+	v := strings.TrimSuffix(strings.TrimPrefix(s, "{"), "}")
+	if len(v) != 36 || v[8] != '-' || v[13] != '-' || v[18] != '-' || v[23] != '-' {
+		return Guid{}, fmt.Errorf("edm: invalid Edm.Guid %q", s)
+	}
+
+	var g Guid
+	h := v[0:8] + v[9:13] + v[14:18] + v[19:23] + v[24:]
+	if _, err := hex.Decode(g.UUID[:], []byte(h)); err != nil {
+		return Guid{}, fmt.Errorf("edm: invalid Edm.Guid %q", s)
+	}
+	g.Valid = true
+	return g, nil
+}
+
+// MustParseGuid is like ParseGuid but panics if s is not a GUID. It is
+// meant for constants.
+func MustParseGuid(s string) Guid {
+	g, err := ParseGuid(s)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// String returns the GUID in lower case 8-4-4-4-12 form, or "" if g is
+// not valid.
+func (g Guid) String() string {
+	if !g.Valid {
+		return ""
+	}
+	h := hex.EncodeToString(g.UUID[:])
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
+
+// MarshalJSON implements json.Marshaler.
+func (g Guid) MarshalJSON() ([]byte, error) {
+	return quote(g.String(), g.Valid)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (g *Guid) UnmarshalJSON(data []byte) error {
+	if isNull(data) {
+		*g = Guid{}
+		return nil
+	}
+	s, err := unquote(data, "Edm.Guid")
+	if err != nil {
+		return err
+	}
+	*g, err = ParseGuid(s)
+	return err
+}
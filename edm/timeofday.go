@@ -0,0 +1,70 @@
+package edm
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeOfDay is an Edm.TimeOfDay: a clock time without a date or time
+// zone, in the form "15:04:05" with optional fractional seconds.
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+	Valid      bool
+}
+
+// NewTimeOfDay returns the valid TimeOfDay for the given clock time.
+func NewTimeOfDay(hour, minute, second, nanosecond int) TimeOfDay {
+	return TimeOfDay{hour, minute, second, nanosecond, true}
+}
+
+// TimeOfDayOf returns the clock time of t in t's location.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	return NewTimeOfDay(t.Hour(), t.Minute(), t.Second(), t.Nanosecond())
+}
+
+// ParseTimeOfDay parses a time of day of the form "15:04:05.9999999".
+// The seconds may be left out.
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	for _, layout := range []string{"15:04:05.999999999", "15:04"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return TimeOfDayOf(t), nil
+		}
+	}
+	return TimeOfDay{}, fmt.Errorf("edm: invalid Edm.TimeOfDay %q", s)
+}
+
+// String returns the time of day in the form "15:04:05.9999999", or ""
+// if t is not valid.
+func (t TimeOfDay) String() string {
+	if !t.Valid {
+		return ""
+	}
+	s := fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	if t.Nanosecond > 0 {
+		s += "." + strings.TrimRight(fmt.Sprintf("%09d", t.Nanosecond), "0")
+	}
+	return s
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t TimeOfDay) MarshalJSON() ([]byte, error) {
+	return quote(t.String(), t.Valid)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *TimeOfDay) UnmarshalJSON(data []byte) error {
+	if isNull(data) {
+		*t = TimeOfDay{}
+		return nil
+	}
+	s, err := unquote(data, "Edm.TimeOfDay")
+	if err != nil {
+		return err
+	}
+	*t, err = ParseTimeOfDay(s)
+	return err
+}
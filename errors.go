@@ -1,5 +1,12 @@
 package msgraph
 
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
 // GraphAPIError is an implementation of error.
 type GraphAPIError struct {
 	// Message is a string representation of this error.
@@ -12,3 +19,111 @@ type GraphAPIError struct {
 func (e *GraphAPIError) Error() string {
 	return e.Message
 }
+
+// ResponseError is returned when Microsoft Graph answers a request with
+// an error status.
+type ResponseError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Code is Graph's error code, for example
+	// "Request_ResourceNotFound".
+	Code string
+	// Message is Graph's description of the error.
+	Message string
+	// RequestID is the identifier Graph assigned to the request.
+	RequestID string
+	// ClientRequestID is the client-request-id sent with the request.
+	ClientRequestID string
+}
+
+// Error implements the Error interface.
+func (e *ResponseError) Error() string {
+	msg := fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Code != "" {
+		msg = fmt.Sprintf("%s: %s: %s", msg, e.Code, e.Message)
+	}
+	if e.RequestID != "" {
+		msg = fmt.Sprintf("%s (request-id %s)", msg, e.RequestID)
+	}
+	return msg
+}
+
+// checkResponse returns a *ResponseError describing resp if its status
+// is not a success. The body of an error response is consumed.
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	e := &ResponseError{
+		StatusCode:      resp.StatusCode,
+		RequestID:       resp.Header.Get(HeaderRequestID),
+		ClientRequestID: resp.Header.Get(HeaderClientRequestID),
+	}
+	if e.ClientRequestID == "" && resp.Request != nil {
+		e.ClientRequestID = resp.Request.Header.Get(HeaderClientRequestID)
+	}
+
+	var body struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err == nil {
+		e.Code = body.Error.Code
+		e.Message = body.Error.Message
+	}
+	return e
+}
+
+// FieldError describes a property of an entity that failed client-side
+// validation.
+type FieldError struct {
+	// Field is the Graph name of the property, for example
+	// "mailNickname".
+	Field string
+	// Message describes what is wrong with the property.
+	Message string
+}
+
+// Error implements the Error interface.
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationError is returned, before any request is sent, when an
+// entity does not satisfy the requirements of an operation.
+type ValidationError struct {
+	// Errors lists every property that failed validation.
+	Errors []*FieldError
+}
+
+// Error implements the Error interface.
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Error()
+	}
+	return "Validation failed: " + strings.Join(msgs, "; ")
+}
+
+// add records a field error.
+func (e *ValidationError) add(field, format string, args ...interface{}) {
+	e.Errors = append(e.Errors, &FieldError{field, fmt.Sprintf(format, args...)})
+}
+
+// err returns e if any field failed validation, or nil.
+func (e *ValidationError) err() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+// IsNotFound reports whether err is a response in which Graph said the
+// requested object does not exist.
+func IsNotFound(err error) bool {
+	e, ok := err.(*ResponseError)
+	return ok && e.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,267 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+// OnPremisesExtensionAttributes holds the fifteen customizable
+// extension attributes of a user that is synchronized from an
+// on-premises Active Directory. For users that are synchronized, they
+// can only be changed on-premises.
+type OnPremisesExtensionAttributes struct {
+	ExtensionAttribute1  string `json:"extensionAttribute1,omitempty"`
+	ExtensionAttribute2  string `json:"extensionAttribute2,omitempty"`
+	ExtensionAttribute3  string `json:"extensionAttribute3,omitempty"`
+	ExtensionAttribute4  string `json:"extensionAttribute4,omitempty"`
+	ExtensionAttribute5  string `json:"extensionAttribute5,omitempty"`
+	ExtensionAttribute6  string `json:"extensionAttribute6,omitempty"`
+	ExtensionAttribute7  string `json:"extensionAttribute7,omitempty"`
+	ExtensionAttribute8  string `json:"extensionAttribute8,omitempty"`
+	ExtensionAttribute9  string `json:"extensionAttribute9,omitempty"`
+	ExtensionAttribute10 string `json:"extensionAttribute10,omitempty"`
+	ExtensionAttribute11 string `json:"extensionAttribute11,omitempty"`
+	ExtensionAttribute12 string `json:"extensionAttribute12,omitempty"`
+	ExtensionAttribute13 string `json:"extensionAttribute13,omitempty"`
+	ExtensionAttribute14 string `json:"extensionAttribute14,omitempty"`
+	ExtensionAttribute15 string `json:"extensionAttribute15,omitempty"`
+}
+
+// DirectoryExtensionName returns the property name of the directory
+// extension called name that is registered by the application with the
+// given application (client) ID, for example
+// "extension_b7d8e648520f41d3b9c0fdeb91768a0a_employeeNumber".
+func DirectoryExtensionName(appID, name string) string {
+	return "extension_" + strings.Replace(appID, "-", "", -1) + "_" + name
+}
+
+// isExtensionProperty reports whether name is the name of a directory
+// extension or schema extension property. Built-in properties are
+// camel-cased and never contain an underscore, while extension names
+// always do.
+func isExtensionProperty(name string) bool {
+	return strings.Contains(name, "_") && !strings.HasPrefix(name, "@")
+}
+
+// extensible is implemented by entities that carry extension
+// properties.
+type extensible interface {
+	extensionProperties() map[string]json.RawMessage
+}
+
+// getExtension decodes the extension property name of ext into v. It
+// reports whether the property is set.
+func getExtension(ext map[string]json.RawMessage, name string, v interface{}) (bool, error) {
+	data, ok := ext[name]
+	if !ok || string(data) == "null" {
+		return false, nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return true, &FieldError{name, err.Error()}
+	}
+	return true, nil
+}
+
+// setExtension encodes v as the extension property name of *ext.
+func setExtension(ext *map[string]json.RawMessage, name string, v interface{}) error {
+	if !isExtensionProperty(name) {
+		return &FieldError{name, "is not an extension property"}
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return &FieldError{name, err.Error()}
+	}
+	setRaw(ext, name, data)
+	return nil
+}
+
+// ODataTypeOpenTypeExtension is the OData type of open extensions.
+const ODataTypeOpenTypeExtension = "#microsoft.graph.openTypeExtension"
+
+// OpenExtension is an open extension: a named set of untyped properties
+// that an application stores on a resource.
+type OpenExtension struct {
+	// The unique identifier of the extension. Read-only.
+	ID string
+
+	// The name of the extension, usually in reverse DNS form, for
+	// example "com.contoso.hrData".
+	ExtensionName string
+
+	// The custom properties of the extension.
+	Properties map[string]json.RawMessage
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e OpenExtension) MarshalJSON() ([]byte, error) {
+	props := make(map[string]interface{}, len(e.Properties)+2)
+	for k, v := range e.Properties {
+		props[k] = v
+	}
+	props["@odata.type"] = ODataTypeOpenTypeExtension
+	props["extensionName"] = e.ExtensionName
+	if e.ID != "" {
+		props["id"] = e.ID
+	}
+	return json.Marshal(props)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *OpenExtension) UnmarshalJSON(data []byte) error {
+	var props map[string]json.RawMessage
+	if err := json.Unmarshal(data, &props); err != nil {
+		return err
+	}
+
+	*e = OpenExtension{Properties: make(map[string]json.RawMessage)}
+	for k, v := range props {
+		var err error
+		switch {
+		case k == "id":
+			err = json.Unmarshal(v, &e.ID)
+		case k == "extensionName":
+			err = json.Unmarshal(v, &e.ExtensionName)
+		case strings.HasPrefix(k, "@"):
+			// Annotations such as @odata.type.
+		default:
+			e.Properties[k] = v
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get decodes the property name into v. It reports whether the
+// property is set.
+func (e *OpenExtension) Get(name string, v interface{}) (bool, error) {
+	return getExtension(e.Properties, name, v)
+}
+
+// Set encodes v as the property name. The names id and extensionName
+// are reserved.
+func (e *OpenExtension) Set(name string, v interface{}) error {
+	if name == "id" || name == "extensionName" || strings.HasPrefix(name, "@") {
+		return &FieldError{name, "is reserved"}
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return &FieldError{name, err.Error()}
+	}
+	if e.Properties == nil {
+		e.Properties = make(map[string]json.RawMessage)
+	}
+	e.Properties[name] = data
+	return nil
+}
+
+// ListOpenExtensions returns the open extensions of the user with the
+// given ID or user principal name.
+func (api *GraphAPI) ListOpenExtensions(ctx context.Context, id string) ([]OpenExtension, error) {
+	endpoint, err := api.Endpoint(ctx, userResource, "extensions", id)
+	if err != nil {
+		return nil, err
+	}
+
+	c := api.newCollection(ctx, "ListOpenExtensions", userResource, endpoint.String())
+	defer c.Close()
+
+	var extensions []OpenExtension
+	for c.Next() {
+		var e OpenExtension
+		if err := c.Decode(&e); err != nil {
+			return nil, err
+		}
+		extensions = append(extensions, e)
+	}
+	return extensions, c.Err()
+}
+
+// GetOpenExtension retrieves the open extension with the given name of
+// the user with the given ID or user principal name.
+func (api *GraphAPI) GetOpenExtension(ctx context.Context, id, name string) (ext OpenExtension, err error) {
+	ctx, span := api.startOperation(ctx, "GetOpenExtension", userResource)
+	defer func() { endOperation(span, err) }()
+
+	endpoint, err := api.Endpoint(ctx, userResource, "extension", id, name)
+	if err != nil {
+		return
+	}
+	err = api.send(ctx, userResource, "GET", endpoint, nil, &ext)
+	return
+}
+
+// CreateOpenExtension adds an open extension to the user with the
+// given ID or user principal name and returns it.
+func (api *GraphAPI) CreateOpenExtension(ctx context.Context, id string, ext OpenExtension) (created OpenExtension, err error) {
+	ctx, span := api.startOperation(ctx, "CreateOpenExtension", userResource)
+	defer func() { endOperation(span, err) }()
+
+	if ext.ExtensionName == "" {
+		return created, &FieldError{"extensionName", "is required"}
+	}
+
+	log.WithFields(log.Fields{
+		"user":      id,
+		"extension": ext.ExtensionName,
+	}).Info("Creating open extension in Graph API")
+
+	endpoint, err := api.Endpoint(ctx, userResource, "extensions", id)
+	if err != nil {
+		return
+	}
+	err = api.send(ctx, userResource, "POST", endpoint, ext, &created)
+	return
+}
+
+// UpdateOpenExtension changes the given properties of the open
+// extension with the given name of the user with the given ID or user
+// principal name. Properties that are not given are left unchanged; a
+// nil value clears a property.
+func (api *GraphAPI) UpdateOpenExtension(ctx context.Context, id, name string, props map[string]interface{}) (err error) {
+	ctx, span := api.startOperation(ctx, "UpdateOpenExtension", userResource)
+	defer func() { endOperation(span, err) }()
+
+	if len(props) == 0 {
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"user":      id,
+		"extension": name,
+	}).Info("Updating open extension in Graph API")
+
+	body := make(map[string]interface{}, len(props)+1)
+	for k, v := range props {
+		body[k] = v
+	}
+	body["@odata.type"] = ODataTypeOpenTypeExtension
+
+	endpoint, err := api.Endpoint(ctx, userResource, "extension", id, name)
+	if err != nil {
+		return
+	}
+	return api.send(ctx, userResource, "PATCH", endpoint, body, nil)
+}
+
+// DeleteOpenExtension deletes the open extension with the given name of
+// the user with the given ID or user principal name.
+func (api *GraphAPI) DeleteOpenExtension(ctx context.Context, id, name string) (err error) {
+	ctx, span := api.startOperation(ctx, "DeleteOpenExtension", userResource)
+	defer func() { endOperation(span, err) }()
+
+	log.WithFields(log.Fields{
+		"user":      id,
+		"extension": name,
+	}).Info("Deleting open extension from Graph API")
+
+	endpoint, err := api.Endpoint(ctx, userResource, "extension", id, name)
+	if err != nil {
+		return
+	}
+	return api.send(ctx, userResource, "DELETE", endpoint, nil, nil)
+}
@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"sync"
 
 	"github.com/Sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/net/context"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/microsoft/clientcredentials"
@@ -34,6 +37,12 @@ type GraphAPI struct {
 	token        *oauth2.Token
 	httpDebug    bool
 	log          *logrus.Logger
+	metrics      Metrics
+	tracer       trace.Tracer
+	maxRetries   int
+
+	headerMu sync.RWMutex // guards header
+	header   http.Header
 }
 
 func (api *GraphAPI) SetDebug(debug bool) {
@@ -74,7 +83,11 @@ func New(tenantDomain string) (api *GraphAPI) {
 				TokenURL: tokenURL,
 			},
 		},
-		log: log,
+		log:        log,
+		metrics:    nopMetrics{},
+		tracer:     otel.Tracer(instrumentationName),
+		header:     make(http.Header),
+		maxRetries: defaultMaxRetries,
 	}
 	return
 }
@@ -122,7 +135,10 @@ func (api *GraphAPI) Client() (*http.Client, error) {
 		api.log.Debugf("Creating a new http.Client")
 
 		ctx := api.getContext()
-		api.client = api.config.Client(ctx)
+		api.client = oauth2.NewClient(ctx, &metricsTokenSource{
+			api: api,
+			src: api.config.TokenSource(ctx),
+		})
 	}
 	return api.client, nil
 }
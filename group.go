@@ -0,0 +1,591 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/crosse/msgraph/edm"
+	"golang.org/x/net/context"
+)
+
+var groupResource = RegisterResource(Resource{
+	Name:       "Group",
+	APIVersion: APIVersionV1,
+	Resource:   "groups",
+	Routes: map[string]Route{
+		"item": "groups/{id}",
+
+		"members":           "groups/{id}/members",
+		"membersRef":        "groups/{id}/members/$ref",
+		"memberRef":         "groups/{id}/members/{memberId}/$ref",
+		"transitiveMembers": "groups/{id}/transitiveMembers",
+		"owners":            "groups/{id}/owners",
+		"ownersRef":         "groups/{id}/owners/$ref",
+		"ownerRef":          "groups/{id}/owners/{ownerId}/$ref",
+
+		"appRoleAssignments": "groups/{id}/appRoleAssignments",
+	},
+})
+
+// Values of Group.GroupTypes.
+const (
+	// GroupTypeUnified marks a Microsoft 365 group.
+	GroupTypeUnified = "Unified"
+
+	// GroupTypeDynamicMembership marks a group whose members are
+	// determined by its membership rule.
+	GroupTypeDynamicMembership = "DynamicMembership"
+)
+
+// Values of Group.Visibility.
+const (
+	GroupVisibilityPublic           = "Public"
+	GroupVisibilityPrivate          = "Private"
+	GroupVisibilityHiddenMembership = "HiddenMembership"
+)
+
+// Group is an Azure AD group, which can be a Microsoft 365 group or a
+// security group.
+type Group struct {
+	// Describes a classification for the group, such as low, medium or
+	// high business impact.
+	Classification string `json:"classification,omitempty"`
+
+	// The date and time at which the group was created, in UTC.
+	// Read-only.
+	CreatedDateTime *edm.DateTimeOffset `json:"createdDateTime,omitempty"`
+
+	// The date and time at which the group was deleted, in UTC. Only
+	// set for groups in the deleted items container. Read-only.
+	DeletedDateTime *edm.DateTimeOffset `json:"deletedDateTime,omitempty"`
+
+	// An optional description for the group.
+	Description string `json:"description,omitempty"`
+
+	// The display name for the group. This property is required when
+	// a group is created.
+	DisplayName string `json:"displayName,omitempty"`
+
+	// The date and time at which the group is set to expire, in UTC.
+	// Read-only.
+	ExpirationDateTime *edm.DateTimeOffset `json:"expirationDateTime,omitempty"`
+
+	// Specifies the type of group to create. Possible values are
+	// "Unified" to create a Microsoft 365 group, or
+	// "DynamicMembership" for dynamic groups. For all other group
+	// types, like security-enabled groups and email-enabled
+	// security groups, do not set this property.
+	GroupTypes []string `json:"groupTypes,omitempty"`
+
+	// The unique identifier for the group. Read-only.
+	ID string `json:"id,omitempty"`
+
+	// Indicates whether the group can be assigned to an Azure AD
+	// role. Can only be set when the group is created.
+	IsAssignableToRole *bool `json:"isAssignableToRole,omitempty"`
+
+	// The SMTP address for the group. Read-only.
+	Mail string `json:"mail,omitempty"`
+
+	// Specifies whether the group is mail-enabled. This property is
+	// required when a group is created.
+	MailEnabled *bool `json:"mailEnabled,omitempty"`
+
+	// The mail alias for the group, unique in the organization. This
+	// property is required when a group is created.
+	MailNickname string `json:"mailNickname,omitempty"`
+
+	// The rule that determines the members of a dynamic group, for
+	// example `user.department -eq "Sales"`.
+	MembershipRule string `json:"membershipRule,omitempty"`
+
+	// Whether the membership rule of a dynamic group is being
+	// processed, "On" or "Paused".
+	MembershipRuleProcessingState string `json:"membershipRuleProcessingState,omitempty"`
+
+	// Indicates the last time at which the group was synced with the
+	// on-premises directory, in UTC. Read-only.
+	OnPremisesLastSyncDateTime *edm.DateTimeOffset `json:"onPremisesLastSyncDateTime,omitempty"`
+
+	// Indicates whether the group is synced from an on-premises
+	// directory. Read-only.
+	OnPremisesSyncEnabled *bool `json:"onPremisesSyncEnabled,omitempty"`
+
+	// The preferred data location for a Microsoft 365 group in a
+	// multi-geo tenant.
+	PreferredDataLocation string `json:"preferredDataLocation,omitempty"`
+
+	// The email addresses of the group. Read-only.
+	ProxyAddresses ProxyAddresses `json:"proxyAddresses,omitempty"`
+
+	// The date and time at which the group was last renewed, in UTC.
+	// Read-only.
+	RenewedDateTime *edm.DateTimeOffset `json:"renewedDateTime,omitempty"`
+
+	// Specifies whether the group is a security group. This property
+	// is required when a group is created.
+	SecurityEnabled *bool `json:"securityEnabled,omitempty"`
+
+	// The security identifier (SID) of the group. Read-only.
+	SecurityIdentifier string `json:"securityIdentifier,omitempty"`
+
+	// The visibility of a Microsoft 365 group: "Public", "Private" or
+	// "HiddenMembership". Defaults to "Public".
+	Visibility string `json:"visibility,omitempty"`
+
+	// AdditionalData holds the properties and annotations returned by
+	// Graph that Group has no field for, keyed by their names.
+	AdditionalData map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler. Additional data is encoded
+// alongside the built-in properties.
+func (g Group) MarshalJSON() ([]byte, error) {
+	type group Group
+	return marshalWithAdditional(group(g), g.AdditionalData)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (g *Group) UnmarshalJSON(data []byte) error {
+	type group Group
+	if err := json.Unmarshal(data, (*group)(g)); err != nil {
+		return err
+	}
+	unknown, err := unknownProperties(data, reflect.TypeOf(*g))
+	g.AdditionalData = unknown
+	return err
+}
+
+// ObjectID implements DirectoryObject.
+func (g *Group) ObjectID() string { return g.ID }
+
+// ObjectType implements DirectoryObject.
+func (g *Group) ObjectType() string { return ODataTypeGroup }
+
+// hasType reports whether t is one of the group's types.
+func (g *Group) hasType(t string) bool {
+	for _, gt := range g.GroupTypes {
+		if strings.EqualFold(gt, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMicrosoft365 reports whether the group is a Microsoft 365 group.
+func (g *Group) IsMicrosoft365() bool {
+	return g.hasType(GroupTypeUnified)
+}
+
+// IsDynamic reports whether the members of the group are determined by
+// its membership rule.
+func (g *Group) IsDynamic() bool {
+	return g.hasType(GroupTypeDynamicMembership)
+}
+
+// IsSecurity reports whether the group is a security group.
+func (g *Group) IsSecurity() bool {
+	return g.SecurityEnabled != nil && *g.SecurityEnabled
+}
+
+// IsMailEnabled reports whether the group is mail-enabled.
+func (g *Group) IsMailEnabled() bool {
+	return g.MailEnabled != nil && *g.MailEnabled
+}
+
+// validateCreate checks the properties required to create a group. Graph
+// can only create Microsoft 365 groups and security groups; mail-enabled
+// security groups and distribution groups must be created in Exchange.
+func (g *Group) validateCreate() error {
+	e := &ValidationError{}
+
+	if strings.TrimSpace(g.DisplayName) == "" {
+		e.add("displayName", "is required")
+	}
+
+	switch {
+	case g.MailNickname == "":
+		e.add("mailNickname", "is required")
+	case len(g.MailNickname) > 64:
+		e.add("mailNickname", "must be at most 64 characters long")
+	default:
+		for _, c := range g.MailNickname {
+			if c > 127 || strings.ContainsRune(mailNicknameInvalid, c) {
+				e.add("mailNickname", "must not contain %q", c)
+				break
+			}
+		}
+	}
+
+	if g.MailEnabled == nil {
+		e.add("mailEnabled", "is required")
+	}
+	if g.SecurityEnabled == nil {
+		e.add("securityEnabled", "is required")
+	}
+	if g.MailEnabled != nil && g.SecurityEnabled != nil {
+		switch {
+		case g.IsMicrosoft365() && !g.IsMailEnabled():
+			e.add("mailEnabled", "must be true for Microsoft 365 groups")
+		case !g.IsMicrosoft365() && g.IsMailEnabled():
+			e.add("mailEnabled", "must be false unless groupTypes includes %q", GroupTypeUnified)
+		case !g.IsMicrosoft365() && !g.IsSecurity():
+			e.add("securityEnabled", "must be true unless groupTypes includes %q", GroupTypeUnified)
+		}
+	}
+
+	switch {
+	case g.IsDynamic() && g.MembershipRule == "":
+		e.add("membershipRule", "is required for dynamic groups")
+	case !g.IsDynamic() && g.MembershipRule != "":
+		e.add("membershipRule", "requires groupTypes to include %q", GroupTypeDynamicMembership)
+	}
+
+	if g.Visibility != "" && !g.IsMicrosoft365() {
+		e.add("visibility", "can only be set for Microsoft 365 groups")
+	}
+
+	return e.err()
+}
+
+// GetGroup retrieves the properties of the group with the given ID.
+// properties may be empty to retrieve the default properties.
+func (api *GraphAPI) GetGroup(ctx context.Context, id string, properties []string) (group Group, err error) {
+	ctx, span := api.startOperation(ctx, "GetGroup", groupResource)
+	defer func() { endOperation(span, err) }()
+
+	log.WithFields(log.Fields{
+		"group": id,
+	}).Info("Getting group from Graph API")
+
+	endpoint, err := api.Endpoint(ctx, groupResource, "item", id)
+	if err != nil {
+		return
+	}
+	ctx = (&Query{Select: properties}).apply(ctx, endpoint)
+
+	err = api.send(ctx, groupResource, "GET", endpoint, nil, &group)
+	return
+}
+
+// GroupIterator iterates over the groups returned by ListGroups.
+type GroupIterator struct {
+	c     *Collection
+	group Group
+}
+
+// Next advances the iterator to the next group, which can then be read
+// with Group. It returns false when there are no more groups or an
+// error occurred; Err tells the two apart.
+func (it *GroupIterator) Next() bool {
+	if !it.c.Next() {
+		return false
+	}
+	it.group = Group{}
+	if err := it.c.Decode(&it.group); err != nil {
+		it.c.Close()
+		return false
+	}
+	return true
+}
+
+// Group returns the current group.
+func (it *GroupIterator) Group() Group {
+	return it.group
+}
+
+// Count returns the total number of matching groups, if Query.Count was
+// set.
+func (it *GroupIterator) Count() (int64, bool) {
+	return it.c.Count()
+}
+
+// Err returns the first error encountered while iterating.
+func (it *GroupIterator) Err() error {
+	return it.c.Err()
+}
+
+// Close stops the iteration early.
+func (it *GroupIterator) Close() error {
+	return it.c.Close()
+}
+
+// ListGroups returns an iterator over the groups in the directory that
+// match q, which may be nil. Pages are requested as the iterator
+// advances.
+func (api *GraphAPI) ListGroups(ctx context.Context, q *Query) *GroupIterator {
+	log.WithFields(log.Fields{
+		"filter": q.values().Get("$filter"),
+	}).Info("Listing groups from Graph API")
+
+	endpoint, err := api.Endpoint(ctx, groupResource, "")
+	if err != nil {
+		return &GroupIterator{c: errCollection(err)}
+	}
+	ctx = q.apply(ctx, endpoint)
+
+	return &GroupIterator{c: api.newCollection(ctx, "ListGroups", groupResource, endpoint.String())}
+}
+
+// CreateGroupOptions holds the directory objects that become owners and
+// members of a group when it is created.
+type CreateGroupOptions struct {
+	// Owners are the IDs of the users or service principals that own
+	// the group. Microsoft 365 groups created by an application
+	// without a signed-in user should have at least one owner.
+	Owners []string
+
+	// Members are the IDs of the initial members of the group. Dynamic
+	// groups cannot have members added.
+	Members []string
+}
+
+// CreateGroup creates a group and returns it. The group is validated
+// before it is sent; see validateCreate for what Graph can create. opts
+// may be nil.
+func (api *GraphAPI) CreateGroup(ctx context.Context, group Group, opts *CreateGroupOptions) (created Group, err error) {
+	ctx, span := api.startOperation(ctx, "CreateGroup", groupResource)
+	defer func() { endOperation(span, err) }()
+
+	if err = group.validateCreate(); err != nil {
+		return
+	}
+	if opts == nil {
+		opts = &CreateGroupOptions{}
+	}
+	if len(opts.Members) > 0 && group.IsDynamic() {
+		return created, &ValidationError{[]*FieldError{{"members", "cannot be added to dynamic groups"}}}
+	}
+	if len(opts.Owners)+len(opts.Members) > bindBatch {
+		return created, &ValidationError{[]*FieldError{{"members", fmt.Sprintf("at most %d owners and members can be added when a group is created", bindBatch)}}}
+	}
+
+	log.WithFields(log.Fields{
+		"group": group.MailNickname,
+	}).Info("Creating group in Graph API")
+
+	endpoint, err := api.Endpoint(ctx, groupResource, "")
+	if err != nil {
+		return
+	}
+	body, err := withoutNulls(group)
+	if err != nil {
+		return
+	}
+	if len(opts.Owners) > 0 || len(opts.Members) > 0 {
+		var props map[string]json.RawMessage
+		if err = json.Unmarshal(body, &props); err != nil {
+			return
+		}
+		if err = api.bind(ctx, props, "owners@odata.bind", opts.Owners); err != nil {
+			return
+		}
+		if err = api.bind(ctx, props, "members@odata.bind", opts.Members); err != nil {
+			return
+		}
+		if body, err = json.Marshal(props); err != nil {
+			return
+		}
+	}
+
+	err = api.send(ctx, groupResource, "POST", endpoint, body, &created)
+	return
+}
+
+// NewGroupPatch returns an empty Patch for a group.
+func NewGroupPatch() *Patch {
+	return NewPatch(Group{})
+}
+
+// UpdateGroup applies patch to the group with the given ID. Only the
+// properties in the patch are sent.
+func (api *GraphAPI) UpdateGroup(ctx context.Context, id string, patch *Patch) (err error) {
+	ctx, span := api.startOperation(ctx, "UpdateGroup", groupResource)
+	defer func() { endOperation(span, err) }()
+
+	if patch.Len() == 0 {
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"group":      id,
+		"properties": patch.Properties(),
+	}).Info("Updating group in Graph API")
+
+	endpoint, err := api.Endpoint(ctx, groupResource, "item", id)
+	if err != nil {
+		return
+	}
+	return api.send(ctx, groupResource, "PATCH", endpoint, patch, nil)
+}
+
+// DeleteGroup deletes the group with the given ID. Microsoft 365 groups
+// are moved to the deleted items container, from which they can be
+// restored for 30 days; security groups are deleted permanently.
+func (api *GraphAPI) DeleteGroup(ctx context.Context, id string) (err error) {
+	ctx, span := api.startOperation(ctx, "DeleteGroup", groupResource)
+	defer func() { endOperation(span, err) }()
+
+	log.WithFields(log.Fields{
+		"group": id,
+	}).Info("Deleting group from Graph API")
+
+	endpoint, err := api.Endpoint(ctx, groupResource, "item", id)
+	if err != nil {
+		return
+	}
+	return api.send(ctx, groupResource, "DELETE", endpoint, nil, nil)
+}
+
+// ListGroupMembers returns an iterator over the direct members of the
+// group with the given ID: users, groups, devices and service
+// principals. q may be nil.
+func (api *GraphAPI) ListGroupMembers(ctx context.Context, id string, q *Query) *DirectoryObjectIterator {
+	return api.listGroupObjects(ctx, "ListGroupMembers", "members", id, q)
+}
+
+// ListGroupTransitiveMembers is like ListGroupMembers, but also returns
+// the members of nested groups.
+func (api *GraphAPI) ListGroupTransitiveMembers(ctx context.Context, id string, q *Query) *DirectoryObjectIterator {
+	return api.listGroupObjects(ctx, "ListGroupTransitiveMembers", "transitiveMembers", id, q)
+}
+
+// ListGroupOwners returns an iterator over the owners of the group with
+// the given ID. q may be nil.
+func (api *GraphAPI) ListGroupOwners(ctx context.Context, id string, q *Query) *DirectoryObjectIterator {
+	return api.listGroupObjects(ctx, "ListGroupOwners", "owners", id, q)
+}
+
+func (api *GraphAPI) listGroupObjects(ctx context.Context, operation, route, id string, q *Query) *DirectoryObjectIterator {
+	endpoint, err := api.Endpoint(ctx, groupResource, route, id)
+	if err != nil {
+		return &DirectoryObjectIterator{c: errCollection(err)}
+	}
+	ctx = q.apply(ctx, endpoint)
+
+	return &DirectoryObjectIterator{c: api.newCollection(ctx, operation, groupResource, endpoint.String())}
+}
+
+// AddGroupMembers adds the directory objects with the given IDs to the
+// members of the group with the given ID. Objects are added in batches
+// of 20; if a batch fails, the earlier batches have been added.
+func (api *GraphAPI) AddGroupMembers(ctx context.Context, id string, memberIDs []string) (err error) {
+	ctx, span := api.startOperation(ctx, "AddGroupMembers", groupResource)
+	defer func() { endOperation(span, err) }()
+
+	log.WithFields(log.Fields{
+		"group":   id,
+		"members": len(memberIDs),
+	}).Info("Adding group members in Graph API")
+
+	endpoint, err := api.Endpoint(ctx, groupResource, "item", id)
+	if err != nil {
+		return
+	}
+	for len(memberIDs) > 0 {
+		n := len(memberIDs)
+		if n > bindBatch {
+			n = bindBatch
+		}
+
+		body := make(map[string]json.RawMessage)
+		if err = api.bind(ctx, body, "members@odata.bind", memberIDs[:n]); err != nil {
+			return
+		}
+		if err = api.send(ctx, groupResource, "PATCH", endpoint, body, nil); err != nil {
+			return
+		}
+		memberIDs = memberIDs[n:]
+	}
+	return nil
+}
+
+// RemoveGroupMember removes the directory object with ID memberID from
+// the members of the group with the given ID.
+func (api *GraphAPI) RemoveGroupMember(ctx context.Context, id, memberID string) (err error) {
+	ctx, span := api.startOperation(ctx, "RemoveGroupMember", groupResource)
+	defer func() { endOperation(span, err) }()
+
+	log.WithFields(log.Fields{
+		"group":  id,
+		"member": memberID,
+	}).Info("Removing group member in Graph API")
+
+	endpoint, err := api.Endpoint(ctx, groupResource, "memberRef", id, memberID)
+	if err != nil {
+		return
+	}
+	return api.send(ctx, groupResource, "DELETE", endpoint, nil, nil)
+}
+
+// AddGroupOwner makes the user or service principal with ID ownerID an
+// owner of the group with the given ID.
+func (api *GraphAPI) AddGroupOwner(ctx context.Context, id, ownerID string) (err error) {
+	ctx, span := api.startOperation(ctx, "AddGroupOwner", groupResource)
+	defer func() { endOperation(span, err) }()
+
+	log.WithFields(log.Fields{
+		"group": id,
+		"owner": ownerID,
+	}).Info("Adding group owner in Graph API")
+
+	endpoint, err := api.Endpoint(ctx, groupResource, "ownersRef", id)
+	if err != nil {
+		return
+	}
+	ref, err := api.Endpoint(ctx, directoryObjectResource, "item", ownerID)
+	if err != nil {
+		return
+	}
+
+	body := map[string]string{"@odata.id": ref.String()}
+	return api.send(ctx, groupResource, "POST", endpoint, body, nil)
+}
+
+// RemoveGroupOwner removes the user or service principal with ID
+// ownerID from the owners of the group with the given ID. Graph does not
+// remove the last owner of a Microsoft 365 group.
+func (api *GraphAPI) RemoveGroupOwner(ctx context.Context, id, ownerID string) (err error) {
+	ctx, span := api.startOperation(ctx, "RemoveGroupOwner", groupResource)
+	defer func() { endOperation(span, err) }()
+
+	log.WithFields(log.Fields{
+		"group": id,
+		"owner": ownerID,
+	}).Info("Removing group owner in Graph API")
+
+	endpoint, err := api.Endpoint(ctx, groupResource, "ownerRef", id, ownerID)
+	if err != nil {
+		return
+	}
+	return api.send(ctx, groupResource, "DELETE", endpoint, nil, nil)
+}
+
+// bindBatch is the number of objects Graph binds in a single request.
+const bindBatch = 20
+
+// bind adds a property such as "members@odata.bind" to props that
+// refers to the directory objects with the given IDs. It does nothing
+// if ids is empty.
+func (api *GraphAPI) bind(ctx context.Context, props map[string]json.RawMessage, property string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	refs := make([]string, len(ids))
+	for i, id := range ids {
+		ref, err := api.Endpoint(ctx, directoryObjectResource, "item", id)
+		if err != nil {
+			return err
+		}
+		refs[i] = ref.String()
+	}
+	data, err := json.Marshal(refs)
+	if err != nil {
+		return &GraphAPIError{fmt.Sprintf("Encoding request: %v", err), err}
+	}
+	props[property] = data
+	return nil
+}
@@ -0,0 +1,102 @@
+package msgraph
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/net/context"
+)
+
+// Headers used to correlate requests with Microsoft Graph's logs.
+const (
+	// HeaderClientRequestID carries a caller-supplied identifier for
+	// a request. Graph echoes it back and Microsoft support can use it
+	// to locate the request.
+	HeaderClientRequestID = "client-request-id"
+
+	// HeaderRequestID carries the identifier Graph assigned to a
+	// request.
+	HeaderRequestID = "request-id"
+)
+
+type contextKey int
+
+const (
+	clientRequestIDKey contextKey = iota
+	headerKey
+	apiVersionKey
+)
+
+// WithClientRequestID returns a copy of ctx that causes requests made
+// with it to use id as their client-request-id instead of a generated
+// one.
+func WithClientRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, clientRequestIDKey, id)
+}
+
+// WithHeader returns a copy of ctx that causes requests made with it to
+// send an extra header, such as "Prefer: outlook.timezone" or
+// "ConsistencyLevel: eventual". Headers added this way take precedence
+// over those set with SetHeader.
+func WithHeader(ctx context.Context, key, value string) context.Context {
+	h := make(http.Header)
+	if parent, ok := ctx.Value(headerKey).(http.Header); ok {
+		for k, v := range parent {
+			h[k] = append([]string(nil), v...)
+		}
+	}
+	h.Add(key, value)
+	return context.WithValue(ctx, headerKey, h)
+}
+
+// SetHeader sets a header that is sent with every request to the
+// Microsoft Graph API. An empty value removes the header. It is safe to
+// call while requests are in flight; they send the headers as they were
+// when they started.
+func (api *GraphAPI) SetHeader(key, value string) {
+	api.log.Debugf("Setting header %v to %v", key, value)
+	api.headerMu.Lock()
+	defer api.headerMu.Unlock()
+	if value == "" {
+		api.header.Del(key)
+		return
+	}
+	api.header.Set(key, value)
+}
+
+// prepareRequest adds the client-level and per-call headers to req, as
+// well as its client-request-id, which is taken from the request's
+// context or generated.
+func (api *GraphAPI) prepareRequest(req *http.Request) {
+	ctx := req.Context()
+
+	api.headerMu.RLock()
+	for k, v := range api.header {
+		req.Header[k] = append([]string(nil), v...)
+	}
+	api.headerMu.RUnlock()
+	if h, ok := ctx.Value(headerKey).(http.Header); ok {
+		for k, v := range h {
+			req.Header[k] = append([]string(nil), v...)
+		}
+	}
+
+	id, _ := ctx.Value(clientRequestIDKey).(string)
+	if id == "" {
+		id = newUUID()
+	}
+	req.Header.Set(HeaderClientRequestID, id)
+	req.Header.Set("return-client-request-id", "true")
+}
+
+// newUUID returns a random (version 4) UUID.
+func newUUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("msgraph: reading random bytes: %v", err))
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
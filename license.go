@@ -0,0 +1,203 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+// subscribedSkuResource holds the commercial subscriptions the tenant
+// has acquired.
+var subscribedSkuResource = RegisterResource(Resource{
+	Name:       "SubscribedSku",
+	APIVersion: APIVersionV1,
+	Resource:   "subscribedSkus",
+	Routes: map[string]Route{
+		"item": "subscribedSkus/{id}",
+	},
+})
+
+// LicenseUnits holds the number of licenses of a subscription in each
+// state.
+type LicenseUnits struct {
+	// The number of units that are enabled.
+	Enabled int `json:"enabled"`
+
+	// The number of units that are suspended because the subscription
+	// was cancelled.
+	Suspended int `json:"suspended"`
+
+	// The number of units that are in warning status because the
+	// subscription expired and was not renewed.
+	Warning int `json:"warning"`
+}
+
+// ServicePlanInfo describes a service plan that is part of a SKU.
+type ServicePlanInfo struct {
+	// The unique identifier of the service plan.
+	ServicePlanID string `json:"servicePlanId"`
+
+	// The name of the service plan, for example "EXCHANGE_S_ENTERPRISE".
+	ServicePlanName string `json:"servicePlanName"`
+
+	// The provisioning status of the service plan.
+	ProvisioningStatus string `json:"provisioningStatus"`
+
+	// The object the service plan can be assigned to, "User" or
+	// "Company".
+	AppliesTo string `json:"appliesTo"`
+}
+
+// Name returns the friendly name of the service plan from the built-in
+// catalog, or its plan name if the plan is not in the catalog.
+func (p ServicePlanInfo) Name() string {
+	if plan, ok := LookupServicePlan(p.ServicePlanID); ok {
+		return plan.Name
+	}
+	return p.ServicePlanName
+}
+
+// SubscribedSku is a commercial subscription the tenant has acquired.
+type SubscribedSku struct {
+	// The unique identifier of the subscribed SKU object.
+	ID string `json:"id"`
+
+	// The unique identifier of the SKU.
+	SKUID string `json:"skuId"`
+
+	// The SKU part number, for example "ENTERPRISEPACK".
+	SKUPartNumber string `json:"skuPartNumber"`
+
+	// The object the SKU can be assigned to, "User" or "Company".
+	AppliesTo string `json:"appliesTo"`
+
+	// Whether the subscription is "Enabled", "Warning", "Suspended",
+	// "Deleted" or "LockedOut".
+	CapabilityStatus string `json:"capabilityStatus"`
+
+	// The number of licenses that have been assigned.
+	ConsumedUnits int `json:"consumedUnits"`
+
+	// The number of licenses in each state.
+	PrepaidUnits LicenseUnits `json:"prepaidUnits"`
+
+	// The service plans that are part of the SKU.
+	ServicePlans []ServicePlanInfo `json:"servicePlans"`
+
+	// AdditionalData holds the properties and annotations returned by
+	// Graph that SubscribedSku has no field for, keyed by their names.
+	AdditionalData map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler. Additional data is encoded
+// alongside the built-in properties.
+func (s SubscribedSku) MarshalJSON() ([]byte, error) {
+	type subscribedSku SubscribedSku
+	return marshalWithAdditional(subscribedSku(s), s.AdditionalData)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *SubscribedSku) UnmarshalJSON(data []byte) error {
+	type subscribedSku SubscribedSku
+	if err := json.Unmarshal(data, (*subscribedSku)(s)); err != nil {
+		return err
+	}
+	unknown, err := unknownProperties(data, reflect.TypeOf(*s))
+	s.AdditionalData = unknown
+	return err
+}
+
+// Available returns the number of enabled licenses that have not been
+// assigned yet. It is negative if more licenses are assigned than are
+// enabled.
+func (s *SubscribedSku) Available() int {
+	return s.PrepaidUnits.Enabled - s.ConsumedUnits
+}
+
+// Name returns the friendly name of the SKU from the built-in catalog,
+// or its part number if the SKU is not in the catalog.
+func (s *SubscribedSku) Name() string {
+	if sku, ok := LookupSKU(s.SKUID); ok {
+		return sku.Name
+	}
+	return s.SKUPartNumber
+}
+
+// ListSubscribedSkus returns the subscriptions the tenant has acquired.
+func (api *GraphAPI) ListSubscribedSkus(ctx context.Context) ([]SubscribedSku, error) {
+	endpoint, err := api.Endpoint(ctx, subscribedSkuResource, "")
+	if err != nil {
+		return nil, err
+	}
+
+	c := api.newCollection(ctx, "ListSubscribedSkus", subscribedSkuResource, endpoint.String())
+	defer c.Close()
+
+	var skus []SubscribedSku
+	for c.Next() {
+		var sku SubscribedSku
+		if err := c.Decode(&sku); err != nil {
+			return nil, err
+		}
+		skus = append(skus, sku)
+	}
+	return skus, c.Err()
+}
+
+// AssignLicense adds and removes licenses of the user with the given ID
+// or user principal name in a single request, and returns the updated
+// user. Licenses in remove are given by their SKU IDs. Both add and
+// remove may be empty, but not both.
+func (api *GraphAPI) AssignLicense(ctx context.Context, id string, add []AssignedLicense, remove []string) (user User, err error) {
+	ctx, span := api.startOperation(ctx, "AssignLicense", userResource)
+	defer func() { endOperation(span, err) }()
+
+	if len(add) == 0 && len(remove) == 0 {
+		return user, &GraphAPIError{"No licenses to add or remove", nil}
+	}
+
+	log.WithFields(log.Fields{
+		"user":   id,
+		"add":    len(add),
+		"remove": len(remove),
+	}).Info("Assigning licenses in Graph API")
+
+	// Graph rejects null for both lists and for disabledPlans.
+	body := struct {
+		AddLicenses    []AssignedLicense `json:"addLicenses"`
+		RemoveLicenses []string          `json:"removeLicenses"`
+	}{
+		AddLicenses:    make([]AssignedLicense, len(add)),
+		RemoveLicenses: remove,
+	}
+	for i, l := range add {
+		if l.DisabledPlans == nil {
+			l.DisabledPlans = []string{}
+		}
+		body.AddLicenses[i] = l
+	}
+	if body.RemoveLicenses == nil {
+		body.RemoveLicenses = []string{}
+	}
+
+	endpoint, err := api.Endpoint(ctx, userResource, "assignLicense", id)
+	if err != nil {
+		return
+	}
+	err = api.send(ctx, userResource, "POST", endpoint, body, &user)
+	return
+}
+
+// ServicePlan returns the service plan of the SKU with the given ID or
+// name, ignoring case.
+func (s *SubscribedSku) ServicePlan(idOrName string) (ServicePlanInfo, bool) {
+	for _, p := range s.ServicePlans {
+		if strings.EqualFold(p.ServicePlanID, idOrName) || strings.EqualFold(p.ServicePlanName, idOrName) {
+			return p, true
+		}
+	}
+	return ServicePlanInfo{}, false
+}
@@ -0,0 +1,664 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// MembershipRule is a parsed dynamic group membership rule, such as
+//
+//	(user.department -eq "Sales") -and (user.proxyAddresses -any (_ -contains "@contoso.com"))
+//
+// It evaluates the rule locally against users, which makes it possible
+// to preview the members of a dynamic group before its rule is changed.
+//
+// Supported are the operators -eq, -ne, -startsWith, -notStartsWith,
+// -contains, -notContains, -match, -notMatch, -in and -notIn, combined
+// with -and, -or, -not and parentheses, and -any and -all on multi-valued
+// properties. Properties are user properties, directory extensions
+// (user.extension_<appid>_<name>) and on-premises extension attributes
+// (user.extensionAttribute1 to 15). Properties User has no field for
+// are looked up in its AdditionalData, and whether they and directory
+// extensions are multi-valued is told from their values. As in Azure
+// AD, comparisons ignore case. Rules on device properties, group membership (memberOf) and
+// "Direct Reports for" rules are not supported.
+type MembershipRule struct {
+	src   string
+	root  ruleNode
+	props map[string]bool
+}
+
+// RuleSyntaxError is returned by ParseMembershipRule for a rule that is
+// not valid.
+type RuleSyntaxError struct {
+	// Pos is the byte offset in the rule at which the error was found.
+	Pos int
+	// Message describes the error.
+	Message string
+}
+
+// Error implements the Error interface.
+func (e *RuleSyntaxError) Error() string {
+	return fmt.Sprintf("membership rule: %s at offset %d", e.Message, e.Pos)
+}
+
+// ParseMembershipRule parses a dynamic membership rule.
+func ParseMembershipRule(rule string) (*MembershipRule, error) {
+	toks, err := lexRule(rule)
+	if err != nil {
+		return nil, err
+	}
+	p := &ruleParser{toks: toks, props: make(map[string]bool)}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, &RuleSyntaxError{t.pos, fmt.Sprintf("unexpected %q", t.text)}
+	}
+	return &MembershipRule{src: rule, root: root, props: p.props}, nil
+}
+
+// String returns the rule as it was parsed.
+func (r *MembershipRule) String() string {
+	return r.src
+}
+
+// Properties returns the user properties the rule refers to, sorted, so
+// that only they need to be selected when users are fetched.
+func (r *MembershipRule) Properties() []string {
+	names := make([]string, 0, len(r.props))
+	for name := range r.props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Match reports whether user satisfies the rule.
+func (r *MembershipRule) Match(user *User) (bool, error) {
+	data, err := json.Marshal(user)
+	if err != nil {
+		return false, &GraphAPIError{fmt.Sprintf("Encoding user: %v", err), err}
+	}
+	var props map[string]interface{}
+	if err := json.Unmarshal(data, &props); err != nil {
+		return false, &GraphAPIError{fmt.Sprintf("Encoding user: %v", err), err}
+	}
+	if ext, ok := props["onPremisesExtensionAttributes"].(map[string]interface{}); ok {
+		for k, v := range ext {
+			props[k] = v
+		}
+	}
+	return r.root.eval(&ruleEnv{user: props})
+}
+
+// Lexer.
+
+type ruleTokenKind int
+
+const (
+	tokEOF ruleTokenKind = iota
+	tokIdent
+	tokString
+	tokOperator
+	tokLParen
+	tokRParen
+	tokLBracket
+	tokRBracket
+	tokComma
+)
+
+type ruleToken struct {
+	kind ruleTokenKind
+	text string
+	pos  int
+}
+
+// lexRule splits a rule into tokens.
+func lexRule(s string) ([]ruleToken, error) {
+	var toks []ruleToken
+	i := 0
+	for i < len(s) {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+		case c == '(':
+			toks = append(toks, ruleToken{tokLParen, "(", i})
+			i++
+		case c == ')':
+			toks = append(toks, ruleToken{tokRParen, ")", i})
+			i++
+		case c == '[':
+			toks = append(toks, ruleToken{tokLBracket, "[", i})
+			i++
+		case c == ']':
+			toks = append(toks, ruleToken{tokRBracket, "]", i})
+			i++
+		case c == ',':
+			toks = append(toks, ruleToken{tokComma, ",", i})
+			i++
+		case c == '"':
+			start := i
+			var b strings.Builder
+			i++
+			for {
+				if i >= len(s) {
+					return nil, &RuleSyntaxError{start, "unterminated string"}
+				}
+				if (s[i] == '\\' || s[i] == '`') && i+1 < len(s) && s[i+1] == '"' {
+					b.WriteByte('"')
+					i += 2
+					continue
+				}
+				if s[i] == '"' {
+					i++
+					break
+				}
+				b.WriteByte(s[i])
+				i++
+			}
+			toks = append(toks, ruleToken{tokString, b.String(), start})
+		case c == '-' && i+1 < len(s) && isRuleLetter(rune(s[i+1])):
+			start := i
+			i++
+			for i < len(s) && isRuleLetter(rune(s[i])) {
+				i++
+			}
+			toks = append(toks, ruleToken{tokOperator, strings.ToLower(s[start:i]), start})
+		case isRuleIdent(rune(c)) || c == '-':
+			start := i
+			i++
+			for i < len(s) && (isRuleIdent(rune(s[i])) || s[i] == '.') {
+				i++
+			}
+			toks = append(toks, ruleToken{tokIdent, s[start:i], start})
+		default:
+			return nil, &RuleSyntaxError{i, fmt.Sprintf("unexpected character %q", c)}
+		}
+	}
+	return append(toks, ruleToken{tokEOF, "end of rule", len(s)}), nil
+}
+
+func isRuleLetter(c rune) bool {
+	return c < unicode.MaxASCII && unicode.IsLetter(c)
+}
+
+func isRuleIdent(c rune) bool {
+	return isRuleLetter(c) || unicode.IsDigit(c) || c == '_'
+}
+
+// Parser.
+
+type ruleParser struct {
+	toks  []ruleToken
+	i     int
+	props map[string]bool
+
+	// elem is the name of the element variable inside -any and -all,
+	// or "" outside of them.
+	elem string
+}
+
+func (p *ruleParser) peek() ruleToken {
+	return p.toks[p.i]
+}
+
+func (p *ruleParser) next() ruleToken {
+	t := p.toks[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *ruleParser) expect(kind ruleTokenKind, what string) (ruleToken, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, &RuleSyntaxError{t.pos, fmt.Sprintf("expected %s, found %q", what, t.text)}
+	}
+	return t, nil
+}
+
+func (p *ruleParser) parseOr() (ruleNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOperator && p.peek().text == "-or" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &ruleOr{left, right}
+	}
+	return left, nil
+}
+
+func (p *ruleParser) parseAnd() (ruleNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOperator && p.peek().text == "-and" {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &ruleAnd{left, right}
+	}
+	return left, nil
+}
+
+func (p *ruleParser) parseUnary() (ruleNode, error) {
+	t := p.peek()
+	switch {
+	case t.kind == tokOperator && t.text == "-not":
+		p.next()
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &ruleNot{n}, nil
+	case t.kind == tokLParen:
+		p.next()
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokRParen, `")"`); err != nil {
+			return nil, err
+		}
+		return n, nil
+	}
+	return p.parseComparison()
+}
+
+// ruleOperators are the comparison operators.
+var ruleOperators = map[string]bool{
+	"-eq": true, "-ne": true,
+	"-startswith": true, "-notstartswith": true,
+	"-contains": true, "-notcontains": true,
+	"-match": true, "-notmatch": true,
+	"-in": true, "-notin": true,
+}
+
+func (p *ruleParser) parseComparison() (ruleNode, error) {
+	t, err := p.expect(tokIdent, "a property")
+	if err != nil {
+		return nil, err
+	}
+	prop, err := p.property(t)
+	if err != nil {
+		return nil, err
+	}
+
+	op := p.next()
+	if op.kind != tokOperator {
+		return nil, &RuleSyntaxError{op.pos, fmt.Sprintf("expected an operator, found %q", op.text)}
+	}
+
+	if op.text == "-any" || op.text == "-all" {
+		if prop.arity == aritySingle {
+			return nil, &RuleSyntaxError{op.pos, fmt.Sprintf("%s needs a multi-valued property", op.text)}
+		}
+		if p.elem != "" {
+			return nil, &RuleSyntaxError{op.pos, fmt.Sprintf("%s cannot be nested", op.text)}
+		}
+		if _, err := p.expect(tokLParen, `"("`); err != nil {
+			return nil, err
+		}
+		p.elem = "*"
+		cond, err := p.parseOr()
+		p.elem = ""
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokRParen, `")"`); err != nil {
+			return nil, err
+		}
+		return &ruleQuantifier{prop: prop, all: op.text == "-all", cond: cond}, nil
+	}
+
+	if !ruleOperators[op.text] {
+		return nil, &RuleSyntaxError{op.pos, fmt.Sprintf("unknown operator %q", op.text)}
+	}
+	if prop.arity == arityMulti {
+		return nil, &RuleSyntaxError{op.pos, fmt.Sprintf("%v is multi-valued; use -any or -all", prop.name)}
+	}
+
+	c := &ruleComparison{prop: prop, op: op.text}
+	if op.text == "-in" || op.text == "-notin" {
+		c.list, err = p.parseList()
+	} else {
+		c.value, err = p.parseValue()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if op.text == "-match" || op.text == "-notmatch" {
+		s, ok := c.value.(string)
+		if !ok {
+			return nil, &RuleSyntaxError{op.pos, op.text + " needs a string"}
+		}
+		if c.re, err = regexp.Compile("(?i)" + s); err != nil {
+			return nil, &RuleSyntaxError{op.pos, fmt.Sprintf("invalid regular expression: %v", err)}
+		}
+	}
+	return c, nil
+}
+
+// property resolves a property reference.
+func (p *ruleParser) property(t ruleToken) (ruleProperty, error) {
+	name := t.text
+	if p.elem != "" {
+		// Inside -any or -all, "_" is the element of a collection of
+		// values and "<type>.<property>" a property of an element of
+		// a collection of objects.
+		if name == "_" {
+			return ruleProperty{elem: true, arity: aritySingle}, nil
+		}
+		if i := strings.IndexByte(name, '.'); i > 0 && !strings.HasPrefix(strings.ToLower(name), "user.") {
+			return ruleProperty{name: name[i+1:], elem: true, arity: aritySingle}, nil
+		}
+	}
+
+	lower := strings.ToLower(name)
+	if !strings.HasPrefix(lower, "user.") || len(name) == len("user.") {
+		if strings.HasPrefix(lower, "device.") {
+			return ruleProperty{}, &RuleSyntaxError{t.pos, "device rules are not supported"}
+		}
+		return ruleProperty{}, &RuleSyntaxError{t.pos, fmt.Sprintf("unknown property %q", name)}
+	}
+	name = name[len("user."):]
+
+	switch lower = strings.ToLower(name); {
+	case lower == "objectid":
+		name = "id"
+	case lower == "memberof":
+		return ruleProperty{}, &RuleSyntaxError{t.pos, "memberOf rules are not supported"}
+	case strings.HasPrefix(lower, "extensionattribute"):
+		n := lower[len("extensionattribute"):]
+		if _, ok := onPremisesExtensionAttributeNames[n]; !ok {
+			return ruleProperty{}, &RuleSyntaxError{t.pos, fmt.Sprintf("unknown property %q", t.text)}
+		}
+		p.props["onPremisesExtensionAttributes"] = true
+		return ruleProperty{name: "extensionAttribute" + n, arity: aritySingle}, nil
+	default:
+		canonical, ok := UserSelector.lower[lower]
+		if !ok {
+			// Directory extensions and properties User has no field
+			// for are matched without regard to case, as Azure AD
+			// does.
+			p.props[name] = true
+			return ruleProperty{name: name, fold: true}, nil
+		}
+		name = canonical
+	}
+
+	p.props[name] = true
+	prop := ruleProperty{name: name, arity: aritySingle}
+	if f, ok := jsonProperties(reflect.TypeOf(User{}))[name]; !ok {
+		// A beta property, which has no field either.
+		prop.arity = arityUnknown
+	} else if f.Type.Kind() == reflect.Slice {
+		prop.arity = arityMulti
+	}
+	return prop, nil
+}
+
+// onPremisesExtensionAttributeNames are the numbers of the on-premises
+// extension attributes.
+var onPremisesExtensionAttributeNames = map[string]struct{}{
+	"1": {}, "2": {}, "3": {}, "4": {}, "5": {}, "6": {}, "7": {}, "8": {},
+	"9": {}, "10": {}, "11": {}, "12": {}, "13": {}, "14": {}, "15": {},
+}
+
+// parseValue parses a string, true, false or null.
+func (p *ruleParser) parseValue() (interface{}, error) {
+	t := p.next()
+	switch t.kind {
+	case tokString:
+		return t.text, nil
+	case tokIdent:
+		switch strings.ToLower(t.text) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+		// Unquoted values such as numbers are compared as strings.
+		return t.text, nil
+	}
+	return nil, &RuleSyntaxError{t.pos, fmt.Sprintf("expected a value, found %q", t.text)}
+}
+
+// parseList parses a list of values such as ["a", "b"].
+func (p *ruleParser) parseList() ([]interface{}, error) {
+	if _, err := p.expect(tokLBracket, `"["`); err != nil {
+		return nil, err
+	}
+	var list []interface{}
+	for p.peek().kind != tokRBracket {
+		if len(list) > 0 {
+			if _, err := p.expect(tokComma, `","`); err != nil {
+				return nil, err
+			}
+		}
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+	p.next()
+	return list, nil
+}
+
+// Evaluation.
+
+// ruleEnv holds the values a rule is evaluated against.
+type ruleEnv struct {
+	user map[string]interface{}
+
+	// elem is the current element inside -any and -all.
+	elem interface{}
+}
+
+type ruleNode interface {
+	eval(env *ruleEnv) (bool, error)
+}
+
+// ruleArity tells whether a property is single- or multi-valued.
+type ruleArity int
+
+const (
+	// arityUnknown is for properties whose type is not known, which
+	// are multi-valued if their value is a JSON array.
+	arityUnknown ruleArity = iota
+	aritySingle
+	arityMulti
+)
+
+type ruleProperty struct {
+	name  string
+	elem  bool
+	arity ruleArity
+
+	// fold is set for names that are looked up without regard to
+	// case.
+	fold bool
+}
+
+// value returns the value of the property in env.
+func (p ruleProperty) value(env *ruleEnv) interface{} {
+	switch {
+	case !p.elem && !p.fold:
+		return env.user[p.name]
+	case !p.elem:
+		return lookupFold(env.user, p.name)
+	case p.name == "":
+		return env.elem
+	}
+	obj, _ := env.elem.(map[string]interface{})
+	return lookupFold(obj, p.name)
+}
+
+// lookupFold returns the value of the property of obj with the given
+// name, ignoring case.
+func lookupFold(obj map[string]interface{}, name string) interface{} {
+	if v, ok := obj[name]; ok {
+		return v
+	}
+	for k, v := range obj {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return nil
+}
+
+type ruleAnd struct{ left, right ruleNode }
+
+func (n *ruleAnd) eval(env *ruleEnv) (bool, error) {
+	ok, err := n.left.eval(env)
+	if err != nil || !ok {
+		return false, err
+	}
+	return n.right.eval(env)
+}
+
+type ruleOr struct{ left, right ruleNode }
+
+func (n *ruleOr) eval(env *ruleEnv) (bool, error) {
+	ok, err := n.left.eval(env)
+	if err != nil || ok {
+		return ok, err
+	}
+	return n.right.eval(env)
+}
+
+type ruleNot struct{ n ruleNode }
+
+func (n *ruleNot) eval(env *ruleEnv) (bool, error) {
+	ok, err := n.n.eval(env)
+	return !ok, err
+}
+
+type ruleQuantifier struct {
+	prop ruleProperty
+	all  bool
+	cond ruleNode
+}
+
+func (n *ruleQuantifier) eval(env *ruleEnv) (bool, error) {
+	var values []interface{}
+	switch v := n.prop.value(env).(type) {
+	case []interface{}:
+		values = v
+	case nil:
+	default:
+		// A single value of a property whose type is not known.
+		values = []interface{}{v}
+	}
+	for _, v := range values {
+		ok, err := n.cond.eval(&ruleEnv{user: env.user, elem: v})
+		if err != nil {
+			return false, err
+		}
+		if ok != n.all {
+			// The first match decides -any, the first mismatch -all.
+			return ok, nil
+		}
+	}
+	// -any over no values is false, -all over no values is true.
+	return n.all, nil
+}
+
+type ruleComparison struct {
+	prop  ruleProperty
+	op    string
+	value interface{}
+	list  []interface{}
+	re    *regexp.Regexp
+}
+
+func (n *ruleComparison) eval(env *ruleEnv) (bool, error) {
+	v := n.prop.value(env)
+	if _, ok := v.([]interface{}); ok && n.prop.arity == arityUnknown {
+		return false, &GraphAPIError{fmt.Sprintf("user.%v is multi-valued; use -any or -all", n.prop.name), nil}
+	}
+	s := strings.ToLower(ruleString(v))
+	want := strings.ToLower(ruleString(n.value))
+
+	switch n.op {
+	case "-eq":
+		return ruleEqual(v, n.value), nil
+	case "-ne":
+		return !ruleEqual(v, n.value), nil
+	case "-startswith":
+		return strings.HasPrefix(s, want), nil
+	case "-notstartswith":
+		return !strings.HasPrefix(s, want), nil
+	case "-contains":
+		return strings.Contains(s, want), nil
+	case "-notcontains":
+		return !strings.Contains(s, want), nil
+	case "-match":
+		return n.re.MatchString(ruleString(v)), nil
+	case "-notmatch":
+		return !n.re.MatchString(ruleString(v)), nil
+	case "-in", "-notin":
+		in := false
+		for _, e := range n.list {
+			if ruleEqual(v, e) {
+				in = true
+				break
+			}
+		}
+		return in == (n.op == "-in"), nil
+	}
+	return false, &GraphAPIError{fmt.Sprintf("Unknown operator %v", n.op), nil}
+}
+
+// ruleEqual compares a property value with a rule value, ignoring case.
+// Null equals a missing or empty value.
+func ruleEqual(v, want interface{}) bool {
+	switch want := want.(type) {
+	case nil:
+		return v == nil || v == ""
+	case bool:
+		b, ok := v.(bool)
+		if !ok {
+			return strings.EqualFold(ruleString(v), fmt.Sprint(want))
+		}
+		return b == want
+	}
+	return strings.EqualFold(ruleString(v), ruleString(want))
+}
+
+// ruleString returns a value decoded from JSON as a string.
+func ruleString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return fmt.Sprint(v)
+	case bool:
+		return fmt.Sprint(v)
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
@@ -0,0 +1,194 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// ruleTestUser is the user the rules in TestMembershipRuleMatch are
+// evaluated against.
+const ruleTestUser = `{
+	"id": "1",
+	"accountEnabled": true,
+	"department": "Sales",
+	"city": "Berlin",
+	"country": "DE",
+	"jobTitle": "Lead \"Ops\"",
+	"proxyAddresses": ["SMTP:a@contoso.com", "smtp:b@fabrikam.com"],
+	"skills": [],
+	"assignedPlans": [
+		{"servicePlanId": "p1", "capabilityStatus": "Deleted"},
+		{"servicePlanId": "p2", "capabilityStatus": "Enabled"}
+	],
+	"onPremisesExtensionAttributes": {"extensionAttribute3": "Z"},
+	"extension_abc_employeeNumber": "42",
+	"extension_abc_costCenters": ["100", "200"],
+	"companyName": "Contoso",
+	"otherMails": ["a@example.com", "b@example.org"]
+}`
+
+func TestMembershipRuleMatch(t *testing.T) {
+	var user User
+	if err := json.Unmarshal([]byte(ruleTestUser), &user); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		rule string
+		want bool
+	}{
+		// Comparisons ignore case.
+		{`user.department -eq "sales"`, true},
+		{`user.department -ne "Sales"`, false},
+		{`user.accountEnabled -eq true`, true},
+		{`user.accountEnabled -eq false`, false},
+		{`user.objectId -eq "1"`, true},
+		{`user.city -startsWith "ber"`, true},
+		{`user.city -notStartsWith "ber"`, false},
+		{`user.city -contains "RLI"`, true},
+		{`user.city -notContains "x"`, true},
+		{`user.city -match "^b.*n$"`, true},
+		{`user.city -notMatch "^b"`, false},
+
+		// -and binds more tightly than -or.
+		{`user.country -eq "DE" -or user.department -eq "x" -and user.city -eq "x"`, true},
+		{`user.department -eq "x" -and user.city -eq "x" -or user.country -eq "DE"`, true},
+		{`(user.country -eq "DE" -or user.department -eq "x") -and user.city -eq "x"`, false},
+
+		// -not applies to the expression that follows it.
+		{`-not user.department -eq "Sales"`, false},
+		{`-not (user.department -eq "x")`, true},
+		{`-not -not user.department -eq "Sales"`, true},
+		{`-not user.department -eq "x" -and user.city -eq "Berlin"`, true},
+
+		// Lists.
+		{`user.department -in ["Marketing", "sales"]`, true},
+		{`user.department -in ["Marketing"]`, false},
+		{`user.department -in []`, false},
+		{`user.department -notIn ["Marketing", "Sales"]`, false},
+		{`user.department -notIn ["Marketing"]`, true},
+
+		// Escaped quotes.
+		{"user.jobTitle -eq \"Lead `\"Ops`\"\"", true},
+		{`user.jobTitle -eq "Lead \"Ops\""`, true},
+
+		// Multi-valued properties, with _ for values and
+		// <type>.<property> for objects.
+		{`user.proxyAddresses -any (_ -contains "fabrikam")`, true},
+		{`user.proxyAddresses -all (_ -contains "contoso")`, false},
+		{`user.proxyAddresses -all (_ -match "@")`, true},
+		{`user.assignedPlans -any (assignedPlan.servicePlanId -eq "p2" -and assignedPlan.capabilityStatus -eq "Enabled")`, true},
+		{`user.assignedPlans -any (assignedPlan.servicePlanId -eq "p1" -and assignedPlan.capabilityStatus -eq "Enabled")`, false},
+		{`user.assignedPlans -all (assignedPlan.capabilityStatus -eq "Enabled")`, false},
+		{`user.skills -any (_ -eq "x")`, false},
+		{`user.skills -all (_ -eq "x")`, true},
+
+		// Null equals a missing or empty value.
+		{`user.mobilePhone -eq null`, true},
+		{`user.mobilePhone -ne null`, false},
+		{`user.department -eq null`, false},
+		{`user.department -ne null`, true},
+
+		// Extensions.
+		{`user.extensionAttribute3 -eq "z"`, true},
+		{`user.extensionAttribute4 -eq null`, true},
+		{`user.extension_abc_employeeNumber -eq "42"`, true},
+		{`user.Extension_ABC_EmployeeNumber -eq "42"`, true},
+		{`user.extension_abc_costCenters -any (_ -eq "200")`, true},
+		{`user.extension_abc_costCenters -all (_ -startsWith "1")`, false},
+		{`user.extension_abc_employeeNumber -any (_ -eq "42")`, true},
+		{`user.extension_abc_missing -any (_ -eq "x")`, false},
+
+		// Properties User has no field for.
+		{`user.companyName -eq "contoso"`, true},
+		{`user.CompanyName -ne "Contoso"`, false},
+		{`user.employeeId -eq null`, true},
+		{`user.employeeId -ne null`, false},
+		{`user.otherMails -any (_ -contains "example.org")`, true},
+		{`user.otherMails -all (_ -contains "example.org")`, false},
+	}
+	for _, tt := range tests {
+		rule, err := ParseMembershipRule(tt.rule)
+		if err != nil {
+			t.Errorf("ParseMembershipRule(%q): %v", tt.rule, err)
+			continue
+		}
+		got, err := rule.Match(&user)
+		if err != nil {
+			t.Errorf("%q: Match: %v", tt.rule, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%q: Match = %v, want %v", tt.rule, got, tt.want)
+		}
+	}
+}
+
+func TestMembershipRuleSyntaxError(t *testing.T) {
+	tests := []struct {
+		rule string
+		pos  int
+	}{
+		{`user.department -eq "x`, 20},
+		{`user.department -eq`, 19},
+		{`user.department -foo "x"`, 16},
+		{`user.department "x"`, 16},
+		{`(user.department -eq "x"`, 24},
+		{`user.department -eq "x" user.city -eq "y"`, 24},
+		{`user.department -eq "a" -or`, 27},
+		{`user.department -eq "a" %`, 24},
+		{`nope -eq "x"`, 0},
+		{`user. -eq "x"`, 0},
+		{`device.deviceOSType -eq "iOS"`, 0},
+		{`user.memberOf -any (group.objectId -in ["x"])`, 0},
+		{`user.extensionAttribute16 -eq "x"`, 0},
+		{`user.department -in "x"`, 20},
+		{`user.department -in ["x" "y"]`, 25},
+		{`user.proxyAddresses -contains "x"`, 20},
+		{`user.department -any (_ -eq "x")`, 16},
+		{`user.proxyAddresses -any (_ -any (_ -eq "x"))`, 28},
+		{`user.department -match "("`, 16},
+	}
+	for _, tt := range tests {
+		_, err := ParseMembershipRule(tt.rule)
+		serr, ok := err.(*RuleSyntaxError)
+		if !ok {
+			t.Errorf("ParseMembershipRule(%q) = %v, want a *RuleSyntaxError", tt.rule, err)
+			continue
+		}
+		if serr.Pos != tt.pos {
+			t.Errorf("ParseMembershipRule(%q): error at %d (%v), want %d", tt.rule, serr.Pos, serr.Message, tt.pos)
+		}
+	}
+}
+
+func TestMembershipRuleProperties(t *testing.T) {
+	rule, err := ParseMembershipRule(`user.objectId -ne null -and user.extensionAttribute1 -eq "x" -and user.proxyAddresses -any (_ -eq "y") -and user.DEPARTMENT -eq "z" -and user.companyName -eq "c"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := rule.Properties()
+	want := []string{"companyName", "department", "id", "onPremisesExtensionAttributes", "proxyAddresses"}
+	if len(got) != len(want) {
+		t.Fatalf("Properties() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Properties() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestMembershipRuleMultiValuedComparison(t *testing.T) {
+	var user User
+	if err := json.Unmarshal([]byte(ruleTestUser), &user); err != nil {
+		t.Fatal(err)
+	}
+	rule, err := ParseMembershipRule(`user.otherMails -eq "a@example.com"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rule.Match(&user); err == nil {
+		t.Errorf("%v: Match succeeded, want an error for a multi-valued property", rule)
+	}
+}
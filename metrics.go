@@ -0,0 +1,77 @@
+package msgraph
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// Metrics receives measurements about the requests a GraphAPI sends to
+// Microsoft Graph. Implementations must be safe for concurrent use.
+// See the prometheus subpackage for an implementation backed by the
+// Prometheus client library.
+type Metrics interface {
+	// ObserveRequest records a single HTTP attempt against a
+	// resource. The status is the HTTP status code returned by
+	// Graph, or 0 if no response was received.
+	ObserveRequest(resource, method string, status int, duration time.Duration)
+
+	// IncThrottled records a response in which Graph asked the
+	// client to back off (HTTP 429 or 503).
+	IncThrottled(resource string)
+
+	// IncRetry records that a request against a resource is being
+	// sent again.
+	IncRetry(resource string)
+
+	// IncTokenRefresh records that a new OAuth2 access token was
+	// obtained.
+	IncTokenRefresh()
+}
+
+// nopMetrics is the Metrics implementation used when none has been set.
+type nopMetrics struct{}
+
+func (nopMetrics) ObserveRequest(string, string, int, time.Duration) {}
+func (nopMetrics) IncThrottled(string)                               {}
+func (nopMetrics) IncRetry(string)                                   {}
+func (nopMetrics) IncTokenRefresh()                                  {}
+
+// SetMetrics sets the Metrics implementation that receives measurements
+// for every request sent to the Microsoft Graph API. Passing nil
+// disables metrics.
+func (api *GraphAPI) SetMetrics(m Metrics) {
+	if m == nil {
+		m = nopMetrics{}
+	}
+	api.metrics = m
+}
+
+// metricsTokenSource wraps a TokenSource and reports every change of
+// access token as a token refresh.
+type metricsTokenSource struct {
+	api *GraphAPI
+	src oauth2.TokenSource
+
+	mu   sync.Mutex
+	last string
+}
+
+func (s *metricsTokenSource) Token() (*oauth2.Token, error) {
+	t, err := s.src.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	refreshed := t.AccessToken != s.last
+	s.last = t.AccessToken
+	s.mu.Unlock()
+
+	if refreshed {
+		s.api.log.Debugf("Obtained a new OAuth2 access token for %v", s.api.TenantDomain)
+		s.api.metrics.IncTokenRefresh()
+	}
+	return t, nil
+}
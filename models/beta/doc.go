@@ -0,0 +1,14 @@
+// Package beta contains the entity, complex and enum types of the
+// Microsoft Graph beta API, generated from its CSDL $metadata document
+// by cmd/msgraph-gen.
+//
+// They are generated from metadata/beta.xml, a pinned excerpt of the
+// metadata with the types this library works with. To generate every
+// type, or to pick up changes to the API, replace it with the full
+// document and run go generate:
+//
+//	curl --create-dirs -o metadata/beta.xml 'https://graph.microsoft.com/beta/$metadata'
+//	go generate ./models/...
+package beta
+
+//go:generate go run ../../cmd/msgraph-gen -metadata ../../metadata/beta.xml -package beta -o models.go
@@ -0,0 +1,14 @@
+// Package v1 contains the entity, complex and enum types of the
+// Microsoft Graph v1.0 API, generated from its CSDL $metadata document
+// by cmd/msgraph-gen.
+//
+// They are generated from metadata/v1.0.xml, a pinned excerpt of the
+// metadata with the types this library works with. To generate every
+// type, or to pick up changes to the API, replace it with the full
+// document and run go generate:
+//
+//	curl --create-dirs -o metadata/v1.0.xml 'https://graph.microsoft.com/v1.0/$metadata'
+//	go generate ./models/...
+package v1
+
+//go:generate go run ../../cmd/msgraph-gen -metadata ../../metadata/v1.0.xml -package v1 -o models.go
@@ -0,0 +1,618 @@
+// Code generated by msgraph-gen from v1.0.xml; DO NOT EDIT.
+
+package v1
+
+import (
+	"github.com/crosse/msgraph/edm"
+)
+
+// AdministrativeUnit is the microsoft.graph.administrativeUnit entity.
+type AdministrativeUnit struct {
+	DirectoryObject
+
+	Description *string `json:"description,omitempty"`
+	DisplayName *string `json:"displayName,omitempty"`
+	Visibility  *string `json:"visibility,omitempty"`
+
+	// Navigation properties, populated only when expanded.
+	Extensions []Extension       `json:"extensions,omitempty"`
+	Members    []DirectoryObject `json:"members,omitempty"`
+}
+
+// GetExtensions returns the extensions navigation property of the administrativeUnit.
+func (e *AdministrativeUnit) GetExtensions() []Extension {
+	if e == nil {
+		return nil
+	}
+	return e.Extensions
+}
+
+// GetMembers returns the members navigation property of the administrativeUnit.
+func (e *AdministrativeUnit) GetMembers() []DirectoryObject {
+	if e == nil {
+		return nil
+	}
+	return e.Members
+}
+
+// AppRole is the microsoft.graph.appRole complex type.
+type AppRole struct {
+	AllowedMemberTypes []string  `json:"allowedMemberTypes,omitempty"`
+	Description        *string   `json:"description,omitempty"`
+	DisplayName        *string   `json:"displayName,omitempty"`
+	ID                 *edm.Guid `json:"id,omitempty"`
+	IsEnabled          bool      `json:"isEnabled,omitempty"`
+	Origin             *string   `json:"origin,omitempty"`
+	Value              *string   `json:"value,omitempty"`
+}
+
+// AppRoleAssignment is the microsoft.graph.appRoleAssignment entity.
+type AppRoleAssignment struct {
+	DirectoryObject
+
+	AppRoleID            *edm.Guid           `json:"appRoleId,omitempty"`
+	CreatedDateTime      *edm.DateTimeOffset `json:"createdDateTime,omitempty"`
+	PrincipalDisplayName *string             `json:"principalDisplayName,omitempty"`
+	PrincipalID          *edm.Guid           `json:"principalId,omitempty"`
+	PrincipalType        *string             `json:"principalType,omitempty"`
+	ResourceDisplayName  *string             `json:"resourceDisplayName,omitempty"`
+	ResourceID           *edm.Guid           `json:"resourceId,omitempty"`
+}
+
+// Application is the microsoft.graph.application entity.
+type Application struct {
+	DirectoryObject
+
+	AppID                  *string                  `json:"appId,omitempty"`
+	AppRoles               []AppRole                `json:"appRoles,omitempty"`
+	CreatedDateTime        *edm.DateTimeOffset      `json:"createdDateTime,omitempty"`
+	Description            *string                  `json:"description,omitempty"`
+	DisplayName            *string                  `json:"displayName,omitempty"`
+	IdentifierUris         []string                 `json:"identifierUris,omitempty"`
+	KeyCredentials         []KeyCredential          `json:"keyCredentials,omitempty"`
+	Notes                  *string                  `json:"notes,omitempty"`
+	PasswordCredentials    []PasswordCredential     `json:"passwordCredentials,omitempty"`
+	PublisherDomain        *string                  `json:"publisherDomain,omitempty"`
+	RequiredResourceAccess []RequiredResourceAccess `json:"requiredResourceAccess,omitempty"`
+	SignInAudience         *string                  `json:"signInAudience,omitempty"`
+	Tags                   []string                 `json:"tags,omitempty"`
+
+	// Navigation properties, populated only when expanded.
+	Owners []DirectoryObject `json:"owners,omitempty"`
+}
+
+// GetOwners returns the owners navigation property of the application.
+func (e *Application) GetOwners() []DirectoryObject {
+	if e == nil {
+		return nil
+	}
+	return e.Owners
+}
+
+// AssignedLicense is the microsoft.graph.assignedLicense complex type.
+type AssignedLicense struct {
+	DisabledPlans []edm.Guid `json:"disabledPlans,omitempty"`
+	SKUID         *edm.Guid  `json:"skuId,omitempty"`
+}
+
+// AssignedPlan is the microsoft.graph.assignedPlan complex type.
+type AssignedPlan struct {
+	AssignedDateTime *edm.DateTimeOffset `json:"assignedDateTime,omitempty"`
+	CapabilityStatus *string             `json:"capabilityStatus,omitempty"`
+	Service          *string             `json:"service,omitempty"`
+	ServicePlanID    *edm.Guid           `json:"servicePlanId,omitempty"`
+}
+
+// DirectoryObject is the microsoft.graph.directoryObject entity.
+type DirectoryObject struct {
+	Entity
+
+	DeletedDateTime *edm.DateTimeOffset `json:"deletedDateTime,omitempty"`
+}
+
+// DirectoryRole is the microsoft.graph.directoryRole entity.
+type DirectoryRole struct {
+	DirectoryObject
+
+	Description    *string `json:"description,omitempty"`
+	DisplayName    *string `json:"displayName,omitempty"`
+	RoleTemplateID *string `json:"roleTemplateId,omitempty"`
+
+	// Navigation properties, populated only when expanded.
+	Members []DirectoryObject `json:"members,omitempty"`
+}
+
+// GetMembers returns the members navigation property of the directoryRole.
+func (e *DirectoryRole) GetMembers() []DirectoryObject {
+	if e == nil {
+		return nil
+	}
+	return e.Members
+}
+
+// EmployeeOrgData is the microsoft.graph.employeeOrgData complex type.
+type EmployeeOrgData struct {
+	CostCenter *string `json:"costCenter,omitempty"`
+	Division   *string `json:"division,omitempty"`
+}
+
+// Entity is the microsoft.graph.entity entity.
+// It is abstract; Graph only returns types derived from it.
+type Entity struct {
+	ID string `json:"id,omitempty"`
+}
+
+// Extension is the microsoft.graph.extension entity.
+type Extension struct {
+	Entity
+}
+
+// Group is the microsoft.graph.group entity.
+type Group struct {
+	DirectoryObject
+
+	Classification                *string             `json:"classification,omitempty"`
+	CreatedDateTime               *edm.DateTimeOffset `json:"createdDateTime,omitempty"`
+	Description                   *string             `json:"description,omitempty"`
+	DisplayName                   *string             `json:"displayName,omitempty"`
+	ExpirationDateTime            *edm.DateTimeOffset `json:"expirationDateTime,omitempty"`
+	GroupTypes                    []string            `json:"groupTypes,omitempty"`
+	IsAssignableToRole            *bool               `json:"isAssignableToRole,omitempty"`
+	Mail                          *string             `json:"mail,omitempty"`
+	MailEnabled                   *bool               `json:"mailEnabled,omitempty"`
+	MailNickname                  *string             `json:"mailNickname,omitempty"`
+	MembershipRule                *string             `json:"membershipRule,omitempty"`
+	MembershipRuleProcessingState *string             `json:"membershipRuleProcessingState,omitempty"`
+	OnPremisesLastSyncDateTime    *edm.DateTimeOffset `json:"onPremisesLastSyncDateTime,omitempty"`
+	OnPremisesSyncEnabled         *bool               `json:"onPremisesSyncEnabled,omitempty"`
+	PreferredDataLocation         *string             `json:"preferredDataLocation,omitempty"`
+	ProxyAddresses                []string            `json:"proxyAddresses,omitempty"`
+	RenewedDateTime               *edm.DateTimeOffset `json:"renewedDateTime,omitempty"`
+	SecurityEnabled               *bool               `json:"securityEnabled,omitempty"`
+	SecurityIdentifier            *string             `json:"securityIdentifier,omitempty"`
+	Visibility                    *string             `json:"visibility,omitempty"`
+
+	// Navigation properties, populated only when expanded.
+	AppRoleAssignments []AppRoleAssignment `json:"appRoleAssignments,omitempty"`
+	Extensions         []Extension         `json:"extensions,omitempty"`
+	MemberOf           []DirectoryObject   `json:"memberOf,omitempty"`
+	Members            []DirectoryObject   `json:"members,omitempty"`
+	Owners             []DirectoryObject   `json:"owners,omitempty"`
+	Photo              *ProfilePhoto       `json:"photo,omitempty"`
+	TransitiveMembers  []DirectoryObject   `json:"transitiveMembers,omitempty"`
+}
+
+// GetAppRoleAssignments returns the appRoleAssignments navigation property of the group.
+func (e *Group) GetAppRoleAssignments() []AppRoleAssignment {
+	if e == nil {
+		return nil
+	}
+	return e.AppRoleAssignments
+}
+
+// GetExtensions returns the extensions navigation property of the group.
+func (e *Group) GetExtensions() []Extension {
+	if e == nil {
+		return nil
+	}
+	return e.Extensions
+}
+
+// GetMemberOf returns the memberOf navigation property of the group.
+func (e *Group) GetMemberOf() []DirectoryObject {
+	if e == nil {
+		return nil
+	}
+	return e.MemberOf
+}
+
+// GetMembers returns the members navigation property of the group.
+func (e *Group) GetMembers() []DirectoryObject {
+	if e == nil {
+		return nil
+	}
+	return e.Members
+}
+
+// GetOwners returns the owners navigation property of the group.
+func (e *Group) GetOwners() []DirectoryObject {
+	if e == nil {
+		return nil
+	}
+	return e.Owners
+}
+
+// GetPhoto returns the photo navigation property of the group.
+func (e *Group) GetPhoto() *ProfilePhoto {
+	if e == nil {
+		return nil
+	}
+	return e.Photo
+}
+
+// GetTransitiveMembers returns the transitiveMembers navigation property of the group.
+func (e *Group) GetTransitiveMembers() []DirectoryObject {
+	if e == nil {
+		return nil
+	}
+	return e.TransitiveMembers
+}
+
+// KeyCredential is the microsoft.graph.keyCredential complex type.
+type KeyCredential struct {
+	CustomKeyIdentifier []byte              `json:"customKeyIdentifier,omitempty"`
+	DisplayName         *string             `json:"displayName,omitempty"`
+	EndDateTime         *edm.DateTimeOffset `json:"endDateTime,omitempty"`
+	Key                 []byte              `json:"key,omitempty"`
+	KeyID               *edm.Guid           `json:"keyId,omitempty"`
+	StartDateTime       *edm.DateTimeOffset `json:"startDateTime,omitempty"`
+	Type                *string             `json:"type,omitempty"`
+	Usage               *string             `json:"usage,omitempty"`
+}
+
+// LicenseAssignmentState is the microsoft.graph.licenseAssignmentState complex type.
+type LicenseAssignmentState struct {
+	AssignedByGroup     *string             `json:"assignedByGroup,omitempty"`
+	DisabledPlans       []edm.Guid          `json:"disabledPlans,omitempty"`
+	Error               *string             `json:"error,omitempty"`
+	LastUpdatedDateTime *edm.DateTimeOffset `json:"lastUpdatedDateTime,omitempty"`
+	SKUID               *edm.Guid           `json:"skuId,omitempty"`
+	State               *string             `json:"state,omitempty"`
+}
+
+// LicenseUnitsDetail is the microsoft.graph.licenseUnitsDetail complex type.
+type LicenseUnitsDetail struct {
+	Enabled   *int32 `json:"enabled,omitempty"`
+	LockedOut *int32 `json:"lockedOut,omitempty"`
+	Suspended *int32 `json:"suspended,omitempty"`
+	Warning   *int32 `json:"warning,omitempty"`
+}
+
+// OAuth2PermissionGrant is the microsoft.graph.oAuth2PermissionGrant entity.
+type OAuth2PermissionGrant struct {
+	Entity
+
+	ClientID    string  `json:"clientId,omitempty"`
+	ConsentType *string `json:"consentType,omitempty"`
+	PrincipalID *string `json:"principalId,omitempty"`
+	ResourceID  string  `json:"resourceId,omitempty"`
+	Scope       *string `json:"scope,omitempty"`
+}
+
+// ObjectIdentity is the microsoft.graph.objectIdentity complex type.
+type ObjectIdentity struct {
+	Issuer           *string `json:"issuer,omitempty"`
+	IssuerAssignedID *string `json:"issuerAssignedId,omitempty"`
+	SignInType       *string `json:"signInType,omitempty"`
+}
+
+// OnPremisesExtensionAttributes is the microsoft.graph.onPremisesExtensionAttributes complex type.
+type OnPremisesExtensionAttributes struct {
+	ExtensionAttribute1  *string `json:"extensionAttribute1,omitempty"`
+	ExtensionAttribute2  *string `json:"extensionAttribute2,omitempty"`
+	ExtensionAttribute3  *string `json:"extensionAttribute3,omitempty"`
+	ExtensionAttribute4  *string `json:"extensionAttribute4,omitempty"`
+	ExtensionAttribute5  *string `json:"extensionAttribute5,omitempty"`
+	ExtensionAttribute6  *string `json:"extensionAttribute6,omitempty"`
+	ExtensionAttribute7  *string `json:"extensionAttribute7,omitempty"`
+	ExtensionAttribute8  *string `json:"extensionAttribute8,omitempty"`
+	ExtensionAttribute9  *string `json:"extensionAttribute9,omitempty"`
+	ExtensionAttribute10 *string `json:"extensionAttribute10,omitempty"`
+	ExtensionAttribute11 *string `json:"extensionAttribute11,omitempty"`
+	ExtensionAttribute12 *string `json:"extensionAttribute12,omitempty"`
+	ExtensionAttribute13 *string `json:"extensionAttribute13,omitempty"`
+	ExtensionAttribute14 *string `json:"extensionAttribute14,omitempty"`
+	ExtensionAttribute15 *string `json:"extensionAttribute15,omitempty"`
+}
+
+// OpenTypeExtension is the microsoft.graph.openTypeExtension entity.
+type OpenTypeExtension struct {
+	Extension
+
+	ExtensionName string `json:"extensionName,omitempty"`
+}
+
+// PasswordCredential is the microsoft.graph.passwordCredential complex type.
+type PasswordCredential struct {
+	CustomKeyIdentifier []byte              `json:"customKeyIdentifier,omitempty"`
+	DisplayName         *string             `json:"displayName,omitempty"`
+	EndDateTime         *edm.DateTimeOffset `json:"endDateTime,omitempty"`
+	Hint                *string             `json:"hint,omitempty"`
+	KeyID               *edm.Guid           `json:"keyId,omitempty"`
+	SecretText          *string             `json:"secretText,omitempty"`
+	StartDateTime       *edm.DateTimeOffset `json:"startDateTime,omitempty"`
+}
+
+// PasswordProfile is the microsoft.graph.passwordProfile complex type.
+type PasswordProfile struct {
+	ForceChangePasswordNextSignIn        *bool   `json:"forceChangePasswordNextSignIn,omitempty"`
+	ForceChangePasswordNextSignInWithMfa *bool   `json:"forceChangePasswordNextSignInWithMfa,omitempty"`
+	Password                             *string `json:"password,omitempty"`
+}
+
+// PermissionScope is the microsoft.graph.permissionScope complex type.
+type PermissionScope struct {
+	AdminConsentDescription *string   `json:"adminConsentDescription,omitempty"`
+	AdminConsentDisplayName *string   `json:"adminConsentDisplayName,omitempty"`
+	ID                      *edm.Guid `json:"id,omitempty"`
+	IsEnabled               bool      `json:"isEnabled,omitempty"`
+	Origin                  *string   `json:"origin,omitempty"`
+	Type                    *string   `json:"type,omitempty"`
+	UserConsentDescription  *string   `json:"userConsentDescription,omitempty"`
+	UserConsentDisplayName  *string   `json:"userConsentDisplayName,omitempty"`
+	Value                   *string   `json:"value,omitempty"`
+}
+
+// ProfilePhoto is the microsoft.graph.profilePhoto entity.
+type ProfilePhoto struct {
+	Entity
+
+	Height *int32 `json:"height,omitempty"`
+	Width  *int32 `json:"width,omitempty"`
+}
+
+// ProvisionedPlan is the microsoft.graph.provisionedPlan complex type.
+type ProvisionedPlan struct {
+	CapabilityStatus   *string `json:"capabilityStatus,omitempty"`
+	ProvisioningStatus *string `json:"provisioningStatus,omitempty"`
+	Service            *string `json:"service,omitempty"`
+}
+
+// RequiredResourceAccess is the microsoft.graph.requiredResourceAccess complex type.
+type RequiredResourceAccess struct {
+	ResourceAccess []ResourceAccess `json:"resourceAccess,omitempty"`
+	ResourceAppID  string           `json:"resourceAppId,omitempty"`
+}
+
+// ResourceAccess is the microsoft.graph.resourceAccess complex type.
+type ResourceAccess struct {
+	ID   *edm.Guid `json:"id,omitempty"`
+	Type *string   `json:"type,omitempty"`
+}
+
+// ServicePlanInfo is the microsoft.graph.servicePlanInfo complex type.
+type ServicePlanInfo struct {
+	AppliesTo          *string   `json:"appliesTo,omitempty"`
+	ProvisioningStatus *string   `json:"provisioningStatus,omitempty"`
+	ServicePlanID      *edm.Guid `json:"servicePlanId,omitempty"`
+	ServicePlanName    *string   `json:"servicePlanName,omitempty"`
+}
+
+// ServicePrincipal is the microsoft.graph.servicePrincipal entity.
+type ServicePrincipal struct {
+	DirectoryObject
+
+	AccountEnabled            *bool                `json:"accountEnabled,omitempty"`
+	AppDisplayName            *string              `json:"appDisplayName,omitempty"`
+	AppID                     *string              `json:"appId,omitempty"`
+	AppOwnerOrganizationID    *edm.Guid            `json:"appOwnerOrganizationId,omitempty"`
+	AppRoleAssignmentRequired bool                 `json:"appRoleAssignmentRequired,omitempty"`
+	AppRoles                  []AppRole            `json:"appRoles,omitempty"`
+	Description               *string              `json:"description,omitempty"`
+	DisplayName               *string              `json:"displayName,omitempty"`
+	KeyCredentials            []KeyCredential      `json:"keyCredentials,omitempty"`
+	Notes                     *string              `json:"notes,omitempty"`
+	Oauth2PermissionScopes    []PermissionScope    `json:"oauth2PermissionScopes,omitempty"`
+	PasswordCredentials       []PasswordCredential `json:"passwordCredentials,omitempty"`
+	PreferredSingleSignOnMode *string              `json:"preferredSingleSignOnMode,omitempty"`
+	ServicePrincipalNames     []string             `json:"servicePrincipalNames,omitempty"`
+	ServicePrincipalType      *string              `json:"servicePrincipalType,omitempty"`
+	SignInAudience            *string              `json:"signInAudience,omitempty"`
+	Tags                      []string             `json:"tags,omitempty"`
+
+	// Navigation properties, populated only when expanded.
+	AppRoleAssignedTo      []AppRoleAssignment     `json:"appRoleAssignedTo,omitempty"`
+	AppRoleAssignments     []AppRoleAssignment     `json:"appRoleAssignments,omitempty"`
+	MemberOf               []DirectoryObject       `json:"memberOf,omitempty"`
+	Oauth2PermissionGrants []OAuth2PermissionGrant `json:"oauth2PermissionGrants,omitempty"`
+	Owners                 []DirectoryObject       `json:"owners,omitempty"`
+}
+
+// GetAppRoleAssignedTo returns the appRoleAssignedTo navigation property of the servicePrincipal.
+func (e *ServicePrincipal) GetAppRoleAssignedTo() []AppRoleAssignment {
+	if e == nil {
+		return nil
+	}
+	return e.AppRoleAssignedTo
+}
+
+// GetAppRoleAssignments returns the appRoleAssignments navigation property of the servicePrincipal.
+func (e *ServicePrincipal) GetAppRoleAssignments() []AppRoleAssignment {
+	if e == nil {
+		return nil
+	}
+	return e.AppRoleAssignments
+}
+
+// GetMemberOf returns the memberOf navigation property of the servicePrincipal.
+func (e *ServicePrincipal) GetMemberOf() []DirectoryObject {
+	if e == nil {
+		return nil
+	}
+	return e.MemberOf
+}
+
+// GetOauth2PermissionGrants returns the oauth2PermissionGrants navigation property of the servicePrincipal.
+func (e *ServicePrincipal) GetOauth2PermissionGrants() []OAuth2PermissionGrant {
+	if e == nil {
+		return nil
+	}
+	return e.Oauth2PermissionGrants
+}
+
+// GetOwners returns the owners navigation property of the servicePrincipal.
+func (e *ServicePrincipal) GetOwners() []DirectoryObject {
+	if e == nil {
+		return nil
+	}
+	return e.Owners
+}
+
+// SubscribedSKU is the microsoft.graph.subscribedSku entity.
+type SubscribedSKU struct {
+	Entity
+
+	AccountID        *string             `json:"accountId,omitempty"`
+	AccountName      *string             `json:"accountName,omitempty"`
+	AppliesTo        *string             `json:"appliesTo,omitempty"`
+	CapabilityStatus *string             `json:"capabilityStatus,omitempty"`
+	ConsumedUnits    *int32              `json:"consumedUnits,omitempty"`
+	PrepaidUnits     *LicenseUnitsDetail `json:"prepaidUnits,omitempty"`
+	ServicePlans     []ServicePlanInfo   `json:"servicePlans,omitempty"`
+	SKUID            *edm.Guid           `json:"skuId,omitempty"`
+	SKUPartNumber    *string             `json:"skuPartNumber,omitempty"`
+	SubscriptionIds  []string            `json:"subscriptionIds,omitempty"`
+}
+
+// User is the microsoft.graph.user entity.
+type User struct {
+	DirectoryObject
+
+	AccountEnabled                  *bool                          `json:"accountEnabled,omitempty"`
+	AgeGroup                        *string                        `json:"ageGroup,omitempty"`
+	AssignedLicenses                []AssignedLicense              `json:"assignedLicenses,omitempty"`
+	AssignedPlans                   []AssignedPlan                 `json:"assignedPlans,omitempty"`
+	Birthday                        *edm.DateTimeOffset            `json:"birthday,omitempty"`
+	BusinessPhones                  []string                       `json:"businessPhones,omitempty"`
+	City                            *string                        `json:"city,omitempty"`
+	CompanyName                     *string                        `json:"companyName,omitempty"`
+	ConsentProvidedForMinor         *string                        `json:"consentProvidedForMinor,omitempty"`
+	Country                         *string                        `json:"country,omitempty"`
+	CreatedDateTime                 *edm.DateTimeOffset            `json:"createdDateTime,omitempty"`
+	CreationType                    *string                        `json:"creationType,omitempty"`
+	Department                      *string                        `json:"department,omitempty"`
+	DisplayName                     *string                        `json:"displayName,omitempty"`
+	EmployeeHireDate                *edm.DateTimeOffset            `json:"employeeHireDate,omitempty"`
+	EmployeeID                      *string                        `json:"employeeId,omitempty"`
+	EmployeeOrgData                 *EmployeeOrgData               `json:"employeeOrgData,omitempty"`
+	EmployeeType                    *string                        `json:"employeeType,omitempty"`
+	ExternalUserState               *string                        `json:"externalUserState,omitempty"`
+	GivenName                       *string                        `json:"givenName,omitempty"`
+	HireDate                        *edm.DateTimeOffset            `json:"hireDate,omitempty"`
+	Identities                      []ObjectIdentity               `json:"identities,omitempty"`
+	ImAddresses                     []string                       `json:"imAddresses,omitempty"`
+	Interests                       []string                       `json:"interests,omitempty"`
+	JobTitle                        *string                        `json:"jobTitle,omitempty"`
+	LastPasswordChangeDateTime      *edm.DateTimeOffset            `json:"lastPasswordChangeDateTime,omitempty"`
+	LegalAgeGroupClassification     *string                        `json:"legalAgeGroupClassification,omitempty"`
+	LicenseAssignmentStates         []LicenseAssignmentState       `json:"licenseAssignmentStates,omitempty"`
+	Mail                            *string                        `json:"mail,omitempty"`
+	MailNickname                    *string                        `json:"mailNickname,omitempty"`
+	MobilePhone                     *string                        `json:"mobilePhone,omitempty"`
+	MySite                          *string                        `json:"mySite,omitempty"`
+	OfficeLocation                  *string                        `json:"officeLocation,omitempty"`
+	OnPremisesDistinguishedName     *string                        `json:"onPremisesDistinguishedName,omitempty"`
+	OnPremisesDomainName            *string                        `json:"onPremisesDomainName,omitempty"`
+	OnPremisesExtensionAttributes   *OnPremisesExtensionAttributes `json:"onPremisesExtensionAttributes,omitempty"`
+	OnPremisesImmutableID           *string                        `json:"onPremisesImmutableId,omitempty"`
+	OnPremisesLastSyncDateTime      *edm.DateTimeOffset            `json:"onPremisesLastSyncDateTime,omitempty"`
+	OnPremisesSamAccountName        *string                        `json:"onPremisesSamAccountName,omitempty"`
+	OnPremisesSecurityIdentifier    *string                        `json:"onPremisesSecurityIdentifier,omitempty"`
+	OnPremisesSyncEnabled           *bool                          `json:"onPremisesSyncEnabled,omitempty"`
+	OnPremisesUserPrincipalName     *string                        `json:"onPremisesUserPrincipalName,omitempty"`
+	OtherMails                      []string                       `json:"otherMails,omitempty"`
+	PasswordPolicies                *string                        `json:"passwordPolicies,omitempty"`
+	PasswordProfile                 *PasswordProfile               `json:"passwordProfile,omitempty"`
+	PastProjects                    []string                       `json:"pastProjects,omitempty"`
+	PostalCode                      *string                        `json:"postalCode,omitempty"`
+	PreferredDataLocation           *string                        `json:"preferredDataLocation,omitempty"`
+	PreferredLanguage               *string                        `json:"preferredLanguage,omitempty"`
+	PreferredName                   *string                        `json:"preferredName,omitempty"`
+	ProvisionedPlans                []ProvisionedPlan              `json:"provisionedPlans,omitempty"`
+	ProxyAddresses                  []string                       `json:"proxyAddresses,omitempty"`
+	Responsibilities                []string                       `json:"responsibilities,omitempty"`
+	Schools                         []string                       `json:"schools,omitempty"`
+	SecurityIdentifier              *string                        `json:"securityIdentifier,omitempty"`
+	ShowInAddressList               *bool                          `json:"showInAddressList,omitempty"`
+	SignInSessionsValidFromDateTime *edm.DateTimeOffset            `json:"signInSessionsValidFromDateTime,omitempty"`
+	Skills                          []string                       `json:"skills,omitempty"`
+	State                           *string                        `json:"state,omitempty"`
+	StreetAddress                   *string                        `json:"streetAddress,omitempty"`
+	Surname                         *string                        `json:"surname,omitempty"`
+	UsageLocation                   *string                        `json:"usageLocation,omitempty"`
+	UserPrincipalName               *string                        `json:"userPrincipalName,omitempty"`
+	UserType                        *string                        `json:"userType,omitempty"`
+
+	// Navigation properties, populated only when expanded.
+	AppRoleAssignments     []AppRoleAssignment     `json:"appRoleAssignments,omitempty"`
+	DirectReports          []DirectoryObject       `json:"directReports,omitempty"`
+	Extensions             []Extension             `json:"extensions,omitempty"`
+	Manager                *DirectoryObject        `json:"manager,omitempty"`
+	MemberOf               []DirectoryObject       `json:"memberOf,omitempty"`
+	Oauth2PermissionGrants []OAuth2PermissionGrant `json:"oauth2PermissionGrants,omitempty"`
+	Photo                  *ProfilePhoto           `json:"photo,omitempty"`
+	Photos                 []ProfilePhoto          `json:"photos,omitempty"`
+	TransitiveMemberOf     []DirectoryObject       `json:"transitiveMemberOf,omitempty"`
+}
+
+// GetAppRoleAssignments returns the appRoleAssignments navigation property of the user.
+func (e *User) GetAppRoleAssignments() []AppRoleAssignment {
+	if e == nil {
+		return nil
+	}
+	return e.AppRoleAssignments
+}
+
+// GetDirectReports returns the directReports navigation property of the user.
+func (e *User) GetDirectReports() []DirectoryObject {
+	if e == nil {
+		return nil
+	}
+	return e.DirectReports
+}
+
+// GetExtensions returns the extensions navigation property of the user.
+func (e *User) GetExtensions() []Extension {
+	if e == nil {
+		return nil
+	}
+	return e.Extensions
+}
+
+// GetManager returns the manager navigation property of the user.
+func (e *User) GetManager() *DirectoryObject {
+	if e == nil {
+		return nil
+	}
+	return e.Manager
+}
+
+// GetMemberOf returns the memberOf navigation property of the user.
+func (e *User) GetMemberOf() []DirectoryObject {
+	if e == nil {
+		return nil
+	}
+	return e.MemberOf
+}
+
+// GetOauth2PermissionGrants returns the oauth2PermissionGrants navigation property of the user.
+func (e *User) GetOauth2PermissionGrants() []OAuth2PermissionGrant {
+	if e == nil {
+		return nil
+	}
+	return e.Oauth2PermissionGrants
+}
+
+// GetPhoto returns the photo navigation property of the user.
+func (e *User) GetPhoto() *ProfilePhoto {
+	if e == nil {
+		return nil
+	}
+	return e.Photo
+}
+
+// GetPhotos returns the photos navigation property of the user.
+func (e *User) GetPhotos() []ProfilePhoto {
+	if e == nil {
+		return nil
+	}
+	return e.Photos
+}
+
+// GetTransitiveMemberOf returns the transitiveMemberOf navigation property of the user.
+func (e *User) GetTransitiveMemberOf() []DirectoryObject {
+	if e == nil {
+		return nil
+	}
+	return e.TransitiveMemberOf
+}
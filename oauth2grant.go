@@ -0,0 +1,246 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+var oauth2PermissionGrantResource = RegisterResource(Resource{
+	Name:       "OAuth2PermissionGrant",
+	APIVersion: APIVersionV1,
+	Resource:   "oauth2PermissionGrants",
+	Routes: map[string]Route{
+		"item": "oauth2PermissionGrants/{id}",
+	},
+})
+
+// Values of OAuth2PermissionGrant.ConsentType.
+const (
+	// ConsentTypeAllPrincipals grants the scopes on behalf of all
+	// users of the tenant.
+	ConsentTypeAllPrincipals = "AllPrincipals"
+
+	// ConsentTypePrincipal grants the scopes on behalf of a single
+	// user.
+	ConsentTypePrincipal = "Principal"
+)
+
+// OAuth2PermissionGrant is a delegated permission grant: it allows a
+// client service principal to access a resource on behalf of users
+// with the given scopes.
+type OAuth2PermissionGrant struct {
+	// The object ID of the client service principal.
+	ClientID string `json:"clientId,omitempty"`
+
+	// Whether the grant applies to all users, ConsentTypeAllPrincipals,
+	// or to the user with PrincipalID, ConsentTypePrincipal.
+	ConsentType string `json:"consentType,omitempty"`
+
+	// The unique identifier of the grant. Read-only.
+	ID string `json:"id,omitempty"`
+
+	// The object ID of the user the grant applies to, if ConsentType
+	// is ConsentTypePrincipal.
+	PrincipalID string `json:"principalId,omitempty"`
+
+	// The object ID of the resource service principal.
+	ResourceID string `json:"resourceId,omitempty"`
+
+	// The granted delegated permissions of the resource, separated by
+	// spaces, such as "User.Read Mail.Read".
+	Scope string `json:"scope,omitempty"`
+
+	// AdditionalData holds the properties and annotations returned by
+	// Graph that OAuth2PermissionGrant has no field for, keyed by their
+	// names.
+	AdditionalData map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler. Additional data is encoded
+// alongside the built-in properties.
+func (g OAuth2PermissionGrant) MarshalJSON() ([]byte, error) {
+	type oauth2PermissionGrant OAuth2PermissionGrant
+	return marshalWithAdditional(oauth2PermissionGrant(g), g.AdditionalData)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (g *OAuth2PermissionGrant) UnmarshalJSON(data []byte) error {
+	type oauth2PermissionGrant OAuth2PermissionGrant
+	if err := json.Unmarshal(data, (*oauth2PermissionGrant)(g)); err != nil {
+		return err
+	}
+	unknown, err := unknownProperties(data, reflect.TypeOf(*g))
+	g.AdditionalData = unknown
+	return err
+}
+
+// Scopes returns the granted scopes.
+func (g *OAuth2PermissionGrant) Scopes() []string {
+	return strings.Fields(g.Scope)
+}
+
+// OAuth2PermissionGrantIterator iterates over delegated permission
+// grants.
+type OAuth2PermissionGrantIterator struct {
+	c     *Collection
+	grant OAuth2PermissionGrant
+}
+
+// Next advances the iterator to the next grant, which can then be read
+// with Grant. It returns false when there are no more grants or an
+// error occurred; Err tells the two apart.
+func (it *OAuth2PermissionGrantIterator) Next() bool {
+	if !it.c.Next() {
+		return false
+	}
+	it.grant = OAuth2PermissionGrant{}
+	if err := it.c.Decode(&it.grant); err != nil {
+		it.c.Close()
+		return false
+	}
+	return true
+}
+
+// Grant returns the current grant.
+func (it *OAuth2PermissionGrantIterator) Grant() OAuth2PermissionGrant {
+	return it.grant
+}
+
+// Count returns the total number of matching grants, if Query.Count was
+// set.
+func (it *OAuth2PermissionGrantIterator) Count() (int64, bool) {
+	return it.c.Count()
+}
+
+// Err returns the first error encountered while iterating.
+func (it *OAuth2PermissionGrantIterator) Err() error {
+	return it.c.Err()
+}
+
+// Close stops the iteration early.
+func (it *OAuth2PermissionGrantIterator) Close() error {
+	return it.c.Close()
+}
+
+// ListOAuth2PermissionGrants returns an iterator over the delegated
+// permission grants in the tenant that match q, which may be nil, for
+// example with the filter "resourceId eq '<id>'".
+func (api *GraphAPI) ListOAuth2PermissionGrants(ctx context.Context, q *Query) *OAuth2PermissionGrantIterator {
+	log.WithFields(log.Fields{
+		"filter": q.values().Get("$filter"),
+	}).Info("Listing OAuth2 permission grants from Graph API")
+
+	endpoint, err := api.Endpoint(ctx, oauth2PermissionGrantResource, "")
+	if err != nil {
+		return &OAuth2PermissionGrantIterator{c: errCollection(err)}
+	}
+	ctx = q.apply(ctx, endpoint)
+
+	return &OAuth2PermissionGrantIterator{c: api.newCollection(ctx, "ListOAuth2PermissionGrants", oauth2PermissionGrantResource, endpoint.String())}
+}
+
+// ListServicePrincipalOAuth2PermissionGrants returns an iterator over
+// the delegated permission grants of the client service principal with
+// the given ID. q may be nil.
+func (api *GraphAPI) ListServicePrincipalOAuth2PermissionGrants(ctx context.Context, id string, q *Query) *OAuth2PermissionGrantIterator {
+	endpoint, err := api.Endpoint(ctx, servicePrincipalResource, "oauth2PermissionGrants", id)
+	if err != nil {
+		return &OAuth2PermissionGrantIterator{c: errCollection(err)}
+	}
+	ctx = q.apply(ctx, endpoint)
+
+	return &OAuth2PermissionGrantIterator{c: api.newCollection(ctx, "ListServicePrincipalOAuth2PermissionGrants", servicePrincipalResource, endpoint.String())}
+}
+
+// CreateOAuth2PermissionGrant grants delegated permissions and returns
+// the grant.
+func (api *GraphAPI) CreateOAuth2PermissionGrant(ctx context.Context, grant OAuth2PermissionGrant) (created OAuth2PermissionGrant, err error) {
+	ctx, span := api.startOperation(ctx, "CreateOAuth2PermissionGrant", oauth2PermissionGrantResource)
+	defer func() { endOperation(span, err) }()
+
+	if err = grant.validateCreate(); err != nil {
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"client":   grant.ClientID,
+		"resource": grant.ResourceID,
+		"scope":    grant.Scope,
+	}).Info("Creating OAuth2 permission grant in Graph API")
+
+	endpoint, err := api.Endpoint(ctx, oauth2PermissionGrantResource, "")
+	if err != nil {
+		return
+	}
+	data, err := withoutNulls(grant)
+	if err != nil {
+		return
+	}
+	err = api.send(ctx, oauth2PermissionGrantResource, "POST", endpoint, data, &created)
+	return
+}
+
+// validateCreate checks the properties required to create a grant.
+func (g *OAuth2PermissionGrant) validateCreate() error {
+	e := &ValidationError{}
+
+	if g.ClientID == "" {
+		e.add("clientId", "is required")
+	}
+	if g.ResourceID == "" {
+		e.add("resourceId", "is required")
+	}
+	switch g.ConsentType {
+	case ConsentTypeAllPrincipals:
+		if g.PrincipalID != "" {
+			e.add("principalId", "must be empty if consentType is %v", ConsentTypeAllPrincipals)
+		}
+	case ConsentTypePrincipal:
+		if g.PrincipalID == "" {
+			e.add("principalId", "is required if consentType is %v", ConsentTypePrincipal)
+		}
+	default:
+		e.add("consentType", "must be %v or %v", ConsentTypeAllPrincipals, ConsentTypePrincipal)
+	}
+
+	return e.err()
+}
+
+// UpdateOAuth2PermissionGrantScope replaces the scopes of the grant with
+// the given ID.
+func (api *GraphAPI) UpdateOAuth2PermissionGrantScope(ctx context.Context, id string, scopes []string) (err error) {
+	ctx, span := api.startOperation(ctx, "UpdateOAuth2PermissionGrantScope", oauth2PermissionGrantResource)
+	defer func() { endOperation(span, err) }()
+
+	log.WithFields(log.Fields{
+		"grant":  id,
+		"scopes": scopes,
+	}).Info("Updating OAuth2 permission grant in Graph API")
+
+	endpoint, err := api.Endpoint(ctx, oauth2PermissionGrantResource, "item", id)
+	if err != nil {
+		return
+	}
+	body := map[string]string{"scope": strings.Join(scopes, " ")}
+	return api.send(ctx, oauth2PermissionGrantResource, "PATCH", endpoint, body, nil)
+}
+
+// DeleteOAuth2PermissionGrant revokes the grant with the given ID.
+func (api *GraphAPI) DeleteOAuth2PermissionGrant(ctx context.Context, id string) (err error) {
+	ctx, span := api.startOperation(ctx, "DeleteOAuth2PermissionGrant", oauth2PermissionGrantResource)
+	defer func() { endOperation(span, err) }()
+
+	log.WithFields(log.Fields{
+		"grant": id,
+	}).Info("Deleting OAuth2 permission grant from Graph API")
+
+	endpoint, err := api.Endpoint(ctx, oauth2PermissionGrantResource, "item", id)
+	if err != nil {
+		return
+	}
+	return api.send(ctx, oauth2PermissionGrantResource, "DELETE", endpoint, nil, nil)
+}
@@ -0,0 +1,176 @@
+package msgraph
+
+import (
+	"strings"
+	"sync"
+
+	"golang.org/x/net/context"
+)
+
+// defaultOrgChartConcurrency is the number of direct report listings
+// OrgChart requests at the same time unless told otherwise.
+const defaultOrgChartConcurrency = 4
+
+// OrgChartNode is a user in an org chart, together with the users who
+// report to them.
+type OrgChartNode struct {
+	// User is the user at this position in the chart.
+	User User
+
+	// Reports are the nodes of the user's direct reports.
+	Reports []*OrgChartNode
+
+	// Cycle is set if the user was already found elsewhere in the
+	// chart, which means the reporting lines loop. The user's reports
+	// are not walked again.
+	Cycle bool
+
+	// Truncated is set if the user's reports were not walked because
+	// the depth limit was reached.
+	Truncated bool
+}
+
+// OrgChartOptions controls how OrgChart walks the reporting lines.
+type OrgChartOptions struct {
+	// MaxDepth is the number of levels of reports below the root to
+	// walk. Zero means no limit.
+	MaxDepth int
+
+	// Concurrency is the number of requests sent at the same time.
+	// Zero means a default of 4.
+	Concurrency int
+
+	// Select lists the user properties to retrieve. The id property
+	// is always retrieved.
+	Select []string
+}
+
+// orgChartWalk holds the state shared by the goroutines of a walk.
+type orgChartWalk struct {
+	api    *GraphAPI
+	ctx    context.Context
+	cancel context.CancelFunc
+	opts   OrgChartOptions
+	sem    chan struct{}
+	wg     sync.WaitGroup
+
+	mu   sync.Mutex
+	seen map[string]bool
+	err  error
+}
+
+// OrgChart walks the reporting lines below the user with the given ID
+// or user principal name and returns them as a tree. Direct reports are
+// fetched concurrently; the walk stops at the first error.
+func (api *GraphAPI) OrgChart(ctx context.Context, id string, opts *OrgChartOptions) (root *OrgChartNode, err error) {
+	ctx, span := api.startOperation(ctx, "OrgChart", userResource)
+	defer func() { endOperation(span, err) }()
+
+	w := &orgChartWalk{
+		api:  api,
+		seen: make(map[string]bool),
+	}
+	if opts != nil {
+		w.opts = *opts
+	}
+	if w.opts.Concurrency <= 0 {
+		w.opts.Concurrency = defaultOrgChartConcurrency
+	}
+	if len(w.opts.Select) > 0 && !selectsID(w.opts.Select) {
+		w.opts.Select = append([]string{"id"}, w.opts.Select...)
+	}
+	w.sem = make(chan struct{}, w.opts.Concurrency)
+	w.ctx, w.cancel = context.WithCancel(ctx)
+	defer w.cancel()
+
+	user, err := api.GetUser(w.ctx, id, w.opts.Select)
+	if err != nil {
+		return nil, err
+	}
+
+	root = &OrgChartNode{User: user}
+	w.visit(root, 0)
+	w.wg.Wait()
+
+	if w.err != nil {
+		return nil, w.err
+	}
+	return root, nil
+}
+
+// selectsID reports whether fields includes the id property, which the
+// walk needs to fetch direct reports.
+func selectsID(fields []string) bool {
+	for _, f := range fields {
+		if strings.EqualFold(f, "id") {
+			return true
+		}
+	}
+	return false
+}
+
+// visit fills in the reports of node, which is at the given depth below
+// the root, and walks each of them in a goroutine of its own.
+func (w *orgChartWalk) visit(node *OrgChartNode, depth int) {
+	w.mu.Lock()
+	if w.seen[node.User.ID] {
+		node.Cycle = true
+		w.mu.Unlock()
+		return
+	}
+	w.seen[node.User.ID] = true
+	w.mu.Unlock()
+
+	if w.opts.MaxDepth > 0 && depth >= w.opts.MaxDepth {
+		node.Truncated = true
+		return
+	}
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		reports, err := w.reports(node.User.ID)
+		if err != nil {
+			w.fail(err)
+			return
+		}
+		for _, r := range reports {
+			child := &OrgChartNode{User: r}
+			node.Reports = append(node.Reports, child)
+		}
+		for _, child := range node.Reports {
+			w.visit(child, depth+1)
+		}
+	}()
+}
+
+// reports lists the direct reports of a user, holding a slot of the
+// concurrency limit while doing so.
+func (w *orgChartWalk) reports(id string) ([]User, error) {
+	select {
+	case w.sem <- struct{}{}:
+	case <-w.ctx.Done():
+		return nil, w.ctx.Err()
+	}
+	defer func() { <-w.sem }()
+
+	it := w.api.ListDirectReports(w.ctx, id, &Query{Select: w.opts.Select})
+	defer it.Close()
+
+	var users []User
+	for it.Next() {
+		users = append(users, it.User())
+	}
+	return users, it.Err()
+}
+
+// fail records the first error of the walk and stops it.
+func (w *orgChartWalk) fail(err error) {
+	w.mu.Lock()
+	if w.err == nil {
+		w.err = err
+	}
+	w.mu.Unlock()
+	w.cancel()
+}
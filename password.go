@@ -0,0 +1,242 @@
+package msgraph
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+
+	log "github.com/Sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+// Password policies that can be set in User.PasswordPolicies.
+const (
+	PasswordPolicyDisablePasswordExpiration = "DisablePasswordExpiration"
+	PasswordPolicyDisableStrongPassword     = "DisableStrongPassword"
+)
+
+// Password length limits of Azure AD.
+const (
+	MinPasswordLength = 8
+	MaxPasswordLength = 256
+
+	defaultPasswordLength = 16
+)
+
+// passwordSymbols are the symbols Azure AD allows in passwords, other
+// than the space.
+const passwordSymbols = "@#$%^&*-_!+=[]{}|\\:',.?/`~\"();<>"
+
+// Character classes of generated passwords. Letters and digits that are
+// easily confused, such as l, 1, O and 0, are left out so that
+// passwords can be read out over the phone.
+const (
+	passwordLower   = "abcdefghijkmnopqrstuvwxyz"
+	passwordUpper   = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	passwordDigits  = "23456789"
+	passwordSpecial = "@#$%^&*-_!+=?"
+)
+
+// HasPasswordPolicy reports whether the comma-separated password
+// policies include policy.
+func HasPasswordPolicy(policies, policy string) bool {
+	for _, p := range strings.Split(policies, ",") {
+		if strings.EqualFold(strings.TrimSpace(p), policy) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckPassword returns an error if password does not satisfy the
+// requirements of Azure AD for a user with the given password policies.
+// Unless the policies include DisableStrongPassword, a password must
+// contain characters of three of the four classes lower case letters,
+// upper case letters, digits and symbols.
+func CheckPassword(password, policies string) error {
+	n := len([]rune(password))
+	if n < MinPasswordLength || n > MaxPasswordLength {
+		return &FieldError{"passwordProfile", fmt.Sprintf("the password must be %d to %d characters long", MinPasswordLength, MaxPasswordLength)}
+	}
+
+	var lower, upper, digit, symbol bool
+	for _, c := range password {
+		switch {
+		case c > unicode.MaxASCII:
+			return &FieldError{"passwordProfile", fmt.Sprintf("the password must not contain %q", c)}
+		case 'a' <= c && c <= 'z':
+			lower = true
+		case 'A' <= c && c <= 'Z':
+			upper = true
+		case '0' <= c && c <= '9':
+			digit = true
+		case c == ' ' || strings.ContainsRune(passwordSymbols, c):
+			symbol = true
+		default:
+			return &FieldError{"passwordProfile", fmt.Sprintf("the password must not contain %q", c)}
+		}
+	}
+
+	if HasPasswordPolicy(policies, PasswordPolicyDisableStrongPassword) {
+		return nil
+	}
+	classes := 0
+	for _, ok := range []bool{lower, upper, digit, symbol} {
+		if ok {
+			classes++
+		}
+	}
+	if classes < 3 {
+		return &FieldError{"passwordProfile", "the password must contain three of lower case letters, upper case letters, digits and symbols"}
+	}
+	return nil
+}
+
+// GeneratePassword returns a random password of the given length, or of
+// 16 characters if length is zero, that satisfies the requirements for a
+// user with the given password policies. Strong passwords contain
+// characters of all four classes; with DisableStrongPassword, only
+// letters and digits are used.
+func GeneratePassword(length int, policies string) (string, error) {
+	if length == 0 {
+		length = defaultPasswordLength
+	}
+	if length < MinPasswordLength || length > MaxPasswordLength {
+		return "", &FieldError{"length", fmt.Sprintf("must be %d to %d", MinPasswordLength, MaxPasswordLength)}
+	}
+
+	classes := []string{passwordLower, passwordUpper, passwordDigits, passwordSpecial}
+	if HasPasswordPolicy(policies, PasswordPolicyDisableStrongPassword) {
+		classes = classes[:3]
+	}
+
+	// One character of each class, the rest from all of them.
+	all := strings.Join(classes, "")
+	password := make([]byte, length)
+	for i := range password {
+		set := all
+		if i < len(classes) {
+			set = classes[i]
+		}
+		c, err := randomIndex(len(set))
+		if err != nil {
+			return "", err
+		}
+		password[i] = set[c]
+	}
+
+	// Shuffle, so that the classes are not at fixed positions.
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := randomIndex(i + 1)
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j] = password[j], password[i]
+	}
+	return string(password), nil
+}
+
+// randomIndex returns a uniformly random number in [0, n).
+func randomIndex(n int) (int, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, &GraphAPIError{fmt.Sprintf("Generating password: %v", err), err}
+	}
+	return int(i.Int64()), nil
+}
+
+// ResetPasswordOptions controls how ResetPassword resets a password.
+type ResetPasswordOptions struct {
+	// Password is the new password. If it is empty, a random password
+	// is generated.
+	Password string
+
+	// Length is the length of a generated password. Zero means 16.
+	Length int
+
+	// ForceChange makes the user change the password on the next
+	// login.
+	ForceChange bool
+
+	// ForceChangeWithMfa makes the user perform multi-factor
+	// authentication before changing the password on the next login.
+	ForceChangeWithMfa bool
+
+	// PasswordPolicies, if not nil, replaces the user's password
+	// policies, for example with "DisablePasswordExpiration".
+	PasswordPolicies *string
+}
+
+// ResetPassword sets a new password for the user with the given ID or
+// user principal name and returns it. The password is checked against,
+// or generated to satisfy, the user's password policies, which are
+// looked up unless opts gives new ones.
+func (api *GraphAPI) ResetPassword(ctx context.Context, id string, opts ResetPasswordOptions) (password string, err error) {
+	ctx, span := api.startOperation(ctx, "ResetPassword", userResource)
+	defer func() { endOperation(span, err) }()
+
+	var policies string
+	if opts.PasswordPolicies != nil {
+		policies = *opts.PasswordPolicies
+	} else {
+		user, err := api.GetUser(ctx, id, []string{"passwordPolicies"})
+		if err != nil {
+			return "", err
+		}
+		policies = user.PasswordPolicies
+	}
+
+	password = opts.Password
+	if password == "" {
+		if password, err = GeneratePassword(opts.Length, policies); err != nil {
+			return "", err
+		}
+	} else if err = CheckPassword(password, policies); err != nil {
+		return "", err
+	}
+
+	log.WithFields(log.Fields{
+		"user":        id,
+		"forceChange": opts.ForceChange,
+	}).Info("Resetting user password in Graph API")
+
+	patch := NewUserPatch()
+	err = patch.Set("passwordProfile", PasswordProfile{
+		ForceChangePasswordOnNextSignIn:      opts.ForceChange,
+		ForceChangePasswordNextSignInWithMfa: opts.ForceChangeWithMfa,
+		Password:                             password,
+	})
+	if err != nil {
+		return "", err
+	}
+	if opts.PasswordPolicies != nil {
+		if err = patch.Set("passwordPolicies", *opts.PasswordPolicies); err != nil {
+			return "", err
+		}
+	}
+
+	if err = api.UpdateUser(ctx, id, patch); err != nil {
+		return "", err
+	}
+	return password, nil
+}
+
+// RevokeSignInSessions invalidates the refresh tokens and session
+// cookies issued to the user with the given ID or user principal name,
+// which makes the user sign in again in every application.
+func (api *GraphAPI) RevokeSignInSessions(ctx context.Context, id string) (err error) {
+	ctx, span := api.startOperation(ctx, "RevokeSignInSessions", userResource)
+	defer func() { endOperation(span, err) }()
+
+	log.WithFields(log.Fields{
+		"user": id,
+	}).Info("Revoking user sign-in sessions in Graph API")
+
+	endpoint, err := api.Endpoint(ctx, userResource, "revokeSignInSessions", id)
+	if err != nil {
+		return
+	}
+	return api.send(ctx, userResource, "POST", endpoint, nil, nil)
+}
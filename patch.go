@@ -0,0 +1,184 @@
+package msgraph
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Patch is a set of changes to the properties of an entity, sent with
+// a PATCH request. Unlike an entity whose empty properties are omitted,
+// a Patch only contains the properties that were set or cleared, and a
+// cleared property is sent as an explicit null.
+type Patch struct {
+	typ   reflect.Type
+	props map[string]json.RawMessage
+}
+
+// NewPatch returns an empty Patch for entities of the same type as
+// entity, for example NewPatch(User{}).
+func NewPatch(entity interface{}) *Patch {
+	t := reflect.TypeOf(entity)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return &Patch{typ: t, props: make(map[string]json.RawMessage)}
+}
+
+// Set marks a property, given by its Graph name, as changed to value.
+func (p *Patch) Set(property string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return &FieldError{property, err.Error()}
+	}
+	return p.SetJSON(property, data)
+}
+
+// SetJSON marks a property, given by its Graph name, as changed to the
+// JSON-encoded value. The value must be valid for the property.
+func (p *Patch) SetJSON(property string, value json.RawMessage) error {
+	if _, ok := jsonProperties(p.typ)[property]; !ok && !p.extensible(property) {
+		return &FieldError{property, fmt.Sprintf("is not a property of %v", p.typ.Name())}
+	}
+
+	// Check the value by decoding it into the entity.
+	doc, err := json.Marshal(map[string]json.RawMessage{property: value})
+	if err != nil {
+		return &FieldError{property, err.Error()}
+	}
+	if err := json.Unmarshal(doc, reflect.New(p.typ).Interface()); err != nil {
+		return &FieldError{property, fmt.Sprintf("invalid value %s", value)}
+	}
+
+	p.props[property] = value
+	return nil
+}
+
+// extensible reports whether property is an extension property of an
+// entity type that carries them.
+func (p *Patch) extensible(property string) bool {
+	return isExtensionProperty(property) && reflect.PtrTo(p.typ).Implements(extensibleType)
+}
+
+var extensibleType = reflect.TypeOf((*extensible)(nil)).Elem()
+
+// Clear marks a property, given by its Graph name, as cleared.
+func (p *Patch) Clear(property string) error {
+	return p.SetJSON(property, json.RawMessage("null"))
+}
+
+// Len returns the number of changed properties.
+func (p *Patch) Len() int {
+	return len(p.props)
+}
+
+// Properties returns the names of the changed properties, sorted.
+func (p *Patch) Properties() []string {
+	names := make([]string, 0, len(p.props))
+	for k := range p.props {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p *Patch) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.props)
+}
+
+// Diff returns the Patch that turns original into modified, which must
+// be entities of the same type. Properties that are set in original but
+// empty in modified are cleared.
+func Diff(original, modified interface{}) (*Patch, error) {
+	p := NewPatch(modified)
+	if t := NewPatch(original).typ; t != p.typ {
+		return nil, &GraphAPIError{fmt.Sprintf("Cannot diff %v and %v", t, p.typ), nil}
+	}
+
+	before, err := entityProperties(original)
+	if err != nil {
+		return nil, err
+	}
+	after, err := entityProperties(modified)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range after {
+		if !bytes.Equal(before[k], v) {
+			p.props[k] = v
+		}
+	}
+	for k, v := range before {
+		if _, ok := after[k]; !ok && string(v) != "null" {
+			p.props[k] = json.RawMessage("null")
+		}
+	}
+	return p, nil
+}
+
+// entityProperties returns the JSON-encoded properties of an entity.
+// Annotations such as @odata.context are left out, as they cannot be
+// changed.
+func entityProperties(entity interface{}) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, &GraphAPIError{fmt.Sprintf("Encoding entity: %v", err), err}
+	}
+	var props map[string]json.RawMessage
+	if err := json.Unmarshal(data, &props); err != nil {
+		return nil, &GraphAPIError{fmt.Sprintf("Encoding entity: %v", err), err}
+	}
+	for k := range props {
+		if strings.HasPrefix(k, "@") {
+			delete(props, k)
+		}
+	}
+	return props, nil
+}
+
+var (
+	jsonPropertiesMu    sync.Mutex
+	jsonPropertiesCache = make(map[reflect.Type]map[string]reflect.StructField)
+)
+
+// jsonProperties returns the fields of struct type t keyed by their
+// JSON names.
+func jsonProperties(t reflect.Type) map[string]reflect.StructField {
+	jsonPropertiesMu.Lock()
+	defer jsonPropertiesMu.Unlock()
+
+	if props, ok := jsonPropertiesCache[t]; ok {
+		return props
+	}
+
+	props := make(map[string]reflect.StructField)
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("json")
+			if f.Anonymous && tag == "" && f.Type.Kind() == reflect.Struct {
+				walk(f.Type)
+				continue
+			}
+			if f.PkgPath != "" || tag == "-" {
+				continue
+			}
+			name := strings.Split(tag, ",")[0]
+			if name == "" {
+				name = f.Name
+			}
+			props[name] = f
+		}
+	}
+	walk(t)
+
+	jsonPropertiesCache[t] = props
+	return props
+}
@@ -0,0 +1,224 @@
+package msgraph
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	log "github.com/Sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+// MaxPhotoSize is the largest photo, in bytes, Graph accepts for a
+// user.
+const MaxPhotoSize = 4 * 1024 * 1024
+
+// PhotoSizes are the sizes in which Graph can return a user's photo, in
+// addition to the size it was uploaded in.
+var PhotoSizes = []string{
+	"48x48", "64x64", "96x96", "120x120", "240x240", "360x360",
+	"432x432", "504x504", "648x648",
+}
+
+// photoContentTypes are the image types Graph accepts for photos.
+var photoContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/bmp":  true,
+}
+
+// ProfilePhoto describes a user's photo.
+type ProfilePhoto struct {
+	// The size of the photo, for example "240x240", or "default" for
+	// the photo in the size it was uploaded in.
+	ID string `json:"id"`
+
+	// The height of the photo in pixels.
+	Height int `json:"height"`
+
+	// The width of the photo in pixels.
+	Width int `json:"width"`
+
+	// The content type of the photo, for example "image/jpeg".
+	ContentType string `json:"@odata.mediaContentType,omitempty"`
+
+	// The entity tag of the photo, which changes when the photo does.
+	ETag string `json:"@odata.mediaEtag,omitempty"`
+
+	// AdditionalData holds the properties and annotations returned by
+	// Graph that ProfilePhoto has no field for, keyed by their names.
+	AdditionalData map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler. Additional data is encoded
+// alongside the built-in properties.
+func (p ProfilePhoto) MarshalJSON() ([]byte, error) {
+	type profilePhoto ProfilePhoto
+	return marshalWithAdditional(profilePhoto(p), p.AdditionalData)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *ProfilePhoto) UnmarshalJSON(data []byte) error {
+	type profilePhoto ProfilePhoto
+	if err := json.Unmarshal(data, (*profilePhoto)(p)); err != nil {
+		return err
+	}
+	unknown, err := unknownProperties(data, reflect.TypeOf(*p))
+	p.AdditionalData = unknown
+	return err
+}
+
+// validPhotoSize returns an error unless size is one of PhotoSizes or
+// empty, which means the photo as uploaded.
+func validPhotoSize(size string) error {
+	if size == "" {
+		return nil
+	}
+	for _, s := range PhotoSizes {
+		if s == size {
+			return nil
+		}
+	}
+	return &FieldError{"size", fmt.Sprintf("%q is not one of %v", size, PhotoSizes)}
+}
+
+// photoEndpoint returns the URL of the photo of a user, or of its
+// content if value is set, in the given size.
+func (api *GraphAPI) photoEndpoint(ctx context.Context, id, size string, value bool) (*url.URL, error) {
+	if err := validPhotoSize(size); err != nil {
+		return nil, err
+	}
+	route, args := "photo", []string{id}
+	if size != "" {
+		route, args = "sizedPhoto", append(args, size)
+	}
+	if value {
+		route += "Value"
+	}
+	return api.Endpoint(ctx, userResource, route, args...)
+}
+
+// GetUserPhotoMetadata retrieves the metadata of the photo of the user
+// with the given ID or user principal name, in the given size or, if
+// size is empty, the size it was uploaded in. If the user has no photo,
+// the error satisfies IsNotFound.
+func (api *GraphAPI) GetUserPhotoMetadata(ctx context.Context, id, size string) (photo ProfilePhoto, err error) {
+	ctx, span := api.startOperation(ctx, "GetUserPhotoMetadata", userResource)
+	defer func() { endOperation(span, err) }()
+
+	endpoint, err := api.photoEndpoint(ctx, id, size, false)
+	if err != nil {
+		return
+	}
+	err = api.send(ctx, userResource, "GET", endpoint, nil, &photo)
+	return
+}
+
+// GetUserPhoto downloads the photo of the user with the given ID or
+// user principal name, in the given size or, if size is empty, the size
+// it was uploaded in. It returns the image and its content type. If the
+// user has no photo, the error satisfies IsNotFound.
+func (api *GraphAPI) GetUserPhoto(ctx context.Context, id, size string) (data []byte, contentType string, err error) {
+	ctx, span := api.startOperation(ctx, "GetUserPhoto", userResource)
+	defer func() { endOperation(span, err) }()
+
+	endpoint, err := api.photoEndpoint(ctx, id, size, true)
+	if err != nil {
+		return
+	}
+	req, err := http.NewRequest("GET", endpoint.String(), nil)
+	if err != nil {
+		return
+	}
+	req = req.WithContext(ctx)
+
+	resp, err := api.do(userResource, req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	if err = checkResponse(resp); err != nil {
+		return
+	}
+
+	data, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", &GraphAPIError{fmt.Sprintf("Reading photo: %v", err), err}
+	}
+	return data, resp.Header.Get("Content-Type"), nil
+}
+
+// CheckPhoto returns the content type of a photo, or an error if it is
+// too large or not an image type Graph accepts.
+func CheckPhoto(data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", &FieldError{"photo", "is empty"}
+	}
+	if len(data) > MaxPhotoSize {
+		return "", &FieldError{"photo", fmt.Sprintf("is %d bytes, more than the maximum of %d", len(data), MaxPhotoSize)}
+	}
+	contentType := http.DetectContentType(data)
+	if !photoContentTypes[contentType] {
+		return "", &FieldError{"photo", fmt.Sprintf("has unsupported content type %v", contentType)}
+	}
+	return contentType, nil
+}
+
+// SetUserPhoto uploads a new photo for the user with the given ID or
+// user principal name. The content type is detected from the data,
+// which is checked with CheckPhoto before it is sent.
+func (api *GraphAPI) SetUserPhoto(ctx context.Context, id string, data []byte) (err error) {
+	ctx, span := api.startOperation(ctx, "SetUserPhoto", userResource)
+	defer func() { endOperation(span, err) }()
+
+	contentType, err := CheckPhoto(data)
+	if err != nil {
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"user":        id,
+		"contentType": contentType,
+		"size":        len(data),
+	}).Info("Uploading user photo to Graph API")
+
+	endpoint, err := api.photoEndpoint(ctx, id, "", true)
+	if err != nil {
+		return
+	}
+	req, err := http.NewRequest("PUT", endpoint.String(), bytes.NewReader(data))
+	if err != nil {
+		return
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := api.do(userResource, req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	return checkResponse(resp)
+}
+
+// DeleteUserPhoto deletes the photo of the user with the given ID or
+// user principal name.
+func (api *GraphAPI) DeleteUserPhoto(ctx context.Context, id string) (err error) {
+	ctx, span := api.startOperation(ctx, "DeleteUserPhoto", userResource)
+	defer func() { endOperation(span, err) }()
+
+	log.WithFields(log.Fields{
+		"user": id,
+	}).Info("Deleting user photo from Graph API")
+
+	endpoint, err := api.Endpoint(ctx, userResource, "photoValue", id)
+	if err != nil {
+		return
+	}
+	return api.send(ctx, userResource, "DELETE", endpoint, nil, nil)
+}
@@ -0,0 +1,108 @@
+// Package prometheus provides a msgraph.Metrics implementation that
+// exports Microsoft Graph request metrics to Prometheus.
+package prometheus
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/crosse/msgraph"
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+var _ msgraph.Metrics = (*Metrics)(nil)
+
+// Metrics is a msgraph.Metrics backed by Prometheus collectors. It is
+// itself a prometheus.Collector, so it can be registered directly:
+//
+//	m := prometheus.NewMetrics("myapp")
+//	registry.MustRegister(m)
+//	api.SetMetrics(m)
+type Metrics struct {
+	requests       *prom.CounterVec
+	latency        *prom.HistogramVec
+	throttled      *prom.CounterVec
+	retries        *prom.CounterVec
+	tokenRefreshes prom.Counter
+}
+
+// NewMetrics creates the collectors for Graph request metrics. Every
+// metric name is prefixed with namespace, if it is not empty.
+func NewMetrics(namespace string) *Metrics {
+	return &Metrics{
+		requests: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "msgraph",
+			Name:      "requests_total",
+			Help:      "Number of HTTP requests sent to Microsoft Graph.",
+		}, []string{"resource", "method", "status"}),
+		latency: prom.NewHistogramVec(prom.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "msgraph",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of HTTP requests sent to Microsoft Graph.",
+			Buckets:   prom.DefBuckets,
+		}, []string{"resource", "method"}),
+		throttled: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "msgraph",
+			Name:      "throttled_total",
+			Help:      "Number of responses in which Microsoft Graph throttled the client.",
+		}, []string{"resource"}),
+		retries: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "msgraph",
+			Name:      "retries_total",
+			Help:      "Number of requests sent to Microsoft Graph again after being throttled.",
+		}, []string{"resource"}),
+		tokenRefreshes: prom.NewCounter(prom.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "msgraph",
+			Name:      "token_refreshes_total",
+			Help:      "Number of OAuth2 access tokens obtained.",
+		}),
+	}
+}
+
+// ObserveRequest implements msgraph.Metrics.
+func (m *Metrics) ObserveRequest(resource, method string, status int, duration time.Duration) {
+	code := "error"
+	if status != 0 {
+		code = strconv.Itoa(status)
+	}
+	m.requests.WithLabelValues(resource, method, code).Inc()
+	m.latency.WithLabelValues(resource, method).Observe(duration.Seconds())
+}
+
+// IncThrottled implements msgraph.Metrics.
+func (m *Metrics) IncThrottled(resource string) {
+	m.throttled.WithLabelValues(resource).Inc()
+}
+
+// IncRetry implements msgraph.Metrics.
+func (m *Metrics) IncRetry(resource string) {
+	m.retries.WithLabelValues(resource).Inc()
+}
+
+// IncTokenRefresh implements msgraph.Metrics.
+func (m *Metrics) IncTokenRefresh() {
+	m.tokenRefreshes.Inc()
+}
+
+// Describe implements prometheus.Collector.
+func (m *Metrics) Describe(ch chan<- *prom.Desc) {
+	m.requests.Describe(ch)
+	m.latency.Describe(ch)
+	m.throttled.Describe(ch)
+	m.retries.Describe(ch)
+	m.tokenRefreshes.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (m *Metrics) Collect(ch chan<- prom.Metric) {
+	m.requests.Collect(ch)
+	m.latency.Collect(ch)
+	m.throttled.Collect(ch)
+	m.retries.Collect(ch)
+	m.tokenRefreshes.Collect(ch)
+}
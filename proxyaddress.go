@@ -0,0 +1,161 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Well-known proxy address protocols. Protocols are matched without
+// regard to case; the case of a protocol only tells primary from
+// secondary addresses.
+const (
+	ProxyProtocolSMTP = "smtp"
+	ProxyProtocolSIP  = "sip"
+	ProxyProtocolX500 = "x500"
+	ProxyProtocolX400 = "x400"
+)
+
+// ProxyAddress is an address of a recipient, in the form
+// "protocol:address". An upper case protocol, as in
+// "SMTP:alice@example.com", marks the primary address of that protocol;
+// a lower case one marks a secondary address (an alias).
+type ProxyAddress struct {
+	// The address' protocol.
+	Protocol string
+
+	// The address.
+	Address string
+}
+
+// ParseProxyAddress parses a proxy address of the form
+// "protocol:address". The protocol may be any non-empty prefix without
+// a colon.
+func ParseProxyAddress(s string) (ProxyAddress, error) {
+	i := strings.Index(s, ":")
+	if i <= 0 || i == len(s)-1 {
+		return ProxyAddress{}, &GraphAPIError{fmt.Sprintf("Invalid proxy address %q", s), nil}
+	}
+	return ProxyAddress{Protocol: s[:i], Address: s[i+1:]}, nil
+}
+
+// String returns the address in the form "protocol:address", or just
+// the address if it has no protocol.
+func (p ProxyAddress) String() string {
+	if p.Protocol == "" {
+		return p.Address
+	}
+	return p.Protocol + ":" + p.Address
+}
+
+// Is reports whether the address uses the given protocol, ignoring
+// case.
+func (p ProxyAddress) Is(protocol string) bool {
+	return strings.EqualFold(p.Protocol, protocol)
+}
+
+// IsPrimary reports whether the address is the primary address of its
+// protocol.
+func (p ProxyAddress) IsPrimary() bool {
+	return p.Protocol != strings.ToLower(p.Protocol)
+}
+
+// MarshalJSON implements json.Marshaler. A ProxyAddress is encoded as
+// a string, as Graph expects.
+func (p ProxyAddress) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. An address without a
+// protocol is kept as it is, with an empty Protocol, so that one odd
+// address does not fail decoding the whole recipient.
+func (p *ProxyAddress) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	addr, err := ParseProxyAddress(s)
+	if err != nil {
+		addr = ProxyAddress{Address: s}
+	}
+	*p = addr
+	return nil
+}
+
+// ProxyAddresses is the list of proxy addresses of a recipient.
+type ProxyAddresses []ProxyAddress
+
+// Primary returns the primary address of the given protocol.
+func (a ProxyAddresses) Primary(protocol string) (ProxyAddress, bool) {
+	for _, p := range a {
+		if p.Is(protocol) && p.IsPrimary() {
+			return p, true
+		}
+	}
+	return ProxyAddress{}, false
+}
+
+// PrimarySMTP returns the primary SMTP address, or "" if there is none.
+func (a ProxyAddresses) PrimarySMTP() string {
+	p, _ := a.Primary(ProxyProtocolSMTP)
+	return p.Address
+}
+
+// find returns the index of the address of the given protocol, or -1.
+// Addresses are compared without regard to case.
+func (a ProxyAddresses) find(protocol, address string) int {
+	for i, p := range a {
+		if p.Is(protocol) && strings.EqualFold(p.Address, address) {
+			return i
+		}
+	}
+	return -1
+}
+
+// AddAlias adds address as a secondary SMTP address, unless it already
+// is one of the addresses.
+func (a *ProxyAddresses) AddAlias(address string) {
+	if a.find(ProxyProtocolSMTP, address) >= 0 {
+		return
+	}
+	*a = append(*a, ProxyAddress{Protocol: ProxyProtocolSMTP, Address: address})
+}
+
+// RemoveAlias removes the secondary SMTP address address. It fails if
+// address is the primary SMTP address, and does nothing if address is
+// not one of the addresses.
+func (a *ProxyAddresses) RemoveAlias(address string) error {
+	i := a.find(ProxyProtocolSMTP, address)
+	if i < 0 {
+		return nil
+	}
+	if (*a)[i].IsPrimary() {
+		return &GraphAPIError{fmt.Sprintf("Cannot remove primary SMTP address %v", address), nil}
+	}
+	*a = append((*a)[:i], (*a)[i+1:]...)
+	return nil
+}
+
+// SetPrimarySMTP makes address the primary SMTP address. The previous
+// primary address is kept as an alias.
+func (a *ProxyAddresses) SetPrimarySMTP(address string) {
+	for i, p := range *a {
+		if p.Is(ProxyProtocolSMTP) && p.IsPrimary() {
+			(*a)[i].Protocol = ProxyProtocolSMTP
+		}
+	}
+	if i := a.find(ProxyProtocolSMTP, address); i >= 0 {
+		(*a)[i].Protocol = strings.ToUpper(ProxyProtocolSMTP)
+		return
+	}
+	*a = append(*a, ProxyAddress{Protocol: strings.ToUpper(ProxyProtocolSMTP), Address: address})
+}
+
+// Strings returns the addresses in the form "protocol:address".
+func (a ProxyAddresses) Strings() []string {
+	s := make([]string, len(a))
+	for i, p := range a {
+		s[i] = p.String()
+	}
+	return s
+}
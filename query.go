@@ -0,0 +1,83 @@
+package msgraph
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/context"
+)
+
+// Query holds the OData query options of a request. The zero value
+// sends no options.
+type Query struct {
+	// Filter restricts the returned items, for example
+	// "startswith(displayName,'a')".
+	Filter string
+
+	// Select lists the properties to return.
+	Select []string
+
+	// Expand lists the navigation properties to return inline.
+	Expand []string
+
+	// OrderBy lists the properties to sort by, each optionally
+	// followed by " desc".
+	OrderBy []string
+
+	// Top is the number of items returned per page. Zero leaves it
+	// to Graph.
+	Top int
+
+	// Search restricts the returned items to those matching a search
+	// expression, for example `"displayName:smith"`.
+	Search string
+
+	// Count asks Graph to include the total number of matching items.
+	Count bool
+}
+
+// values returns the query options as URL query parameters.
+func (q *Query) values() url.Values {
+	v := url.Values{}
+	if q == nil {
+		return v
+	}
+	if q.Filter != "" {
+		v.Set("$filter", q.Filter)
+	}
+	if len(q.Select) > 0 {
+		v.Set("$select", strings.Join(q.Select, ","))
+	}
+	if len(q.Expand) > 0 {
+		v.Set("$expand", strings.Join(q.Expand, ","))
+	}
+	if len(q.OrderBy) > 0 {
+		v.Set("$orderby", strings.Join(q.OrderBy, ","))
+	}
+	if q.Top > 0 {
+		v.Set("$top", strconv.Itoa(q.Top))
+	}
+	if q.Search != "" {
+		v.Set("$search", q.Search)
+	}
+	if q.Count {
+		v.Set("$count", "true")
+	}
+	return v
+}
+
+// apply adds the query options to u and returns the context to send
+// the request with. Directory queries that use $search or $count are
+// "advanced queries" and require the ConsistencyLevel header.
+func (q *Query) apply(ctx context.Context, u *url.URL) context.Context {
+	v := q.values()
+	if len(v) == 0 {
+		return ctx
+	}
+	u.RawQuery = v.Encode()
+	if q.Search != "" || q.Count {
+		ctx = WithHeader(ctx, "ConsistencyLevel", "eventual")
+	}
+	return ctx
+}
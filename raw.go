@@ -0,0 +1,56 @@
+package msgraph
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/context"
+)
+
+// rawResource is the Resource that requests sent through Request are
+// reported under.
+var rawResource = Resource{Name: "Raw"}
+
+// Request sends an authenticated request to an arbitrary Graph path,
+// such as "me/memberOf" or "/groups?$top=5", relative to the root of the
+// given API version. An absolute Graph URL, such as an @odata.nextLink,
+// is used as is. This allows reaching endpoints that have no typed API yet.
+//
+// Unlike the typed API, Request does not treat an error status as an
+// error; the caller must inspect the response and close its body.
+func (api *GraphAPI) Request(ctx context.Context, method string, version APIVersion, path string, body io.Reader) (resp *http.Response, err error) {
+	r := rawResource
+	r.APIVersion = version
+
+	ctx, span := api.startOperation(ctx, "Request", r)
+	defer func() { endOperation(span, err) }()
+
+	var endpoint string
+	switch {
+	case strings.HasPrefix(path, api.config.Endpoint.TokenURL+"/"):
+		endpoint = path
+	case strings.Contains(path, "://"):
+		// Never send the access token anywhere but to Graph.
+		return nil, &GraphAPIError{fmt.Sprintf("%v is not a Microsoft Graph URL", path), nil}
+	default:
+		u, err := api.endpointURL(version, strings.TrimPrefix(path, "/"))
+		if err != nil {
+			return nil, err
+		}
+		endpoint = u.String()
+	}
+	api.log.Debugf("Sending %s request to %s", method, endpoint)
+
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return nil, &GraphAPIError{fmt.Sprintf("Creating request: %v", err), err}
+	}
+	req = req.WithContext(ctx)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return api.do(r, req)
+}
@@ -0,0 +1,162 @@
+package msgraph
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// defaultMaxRetries is the number of times a throttled request is sent
+// again before the throttled response is returned to the caller.
+const defaultMaxRetries = 3
+
+// SetMaxRetries sets how many times a request that Graph throttled is
+// sent again before giving up. Zero disables retries.
+func (api *GraphAPI) SetMaxRetries(n int) {
+	if n < 0 {
+		n = 0
+	}
+	api.log.Debugf("Setting MaxRetries to %v", n)
+	api.maxRetries = n
+}
+
+// isThrottled reports whether Graph asked the client to back off.
+func isThrottled(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable
+}
+
+// retryDelay returns how long to wait before sending a throttled request
+// again. Graph's Retry-After header is honoured when present; otherwise
+// the delay doubles with every attempt.
+func retryDelay(resp *http.Response, attempt int) time.Duration {
+	if s, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second
+	}
+	return time.Duration(1<<uint(attempt)) * time.Second
+}
+
+// do sends req on behalf of resource r. Throttled requests are sent
+// again up to the configured number of retries, and every attempt is
+// reported to the API's Metrics and traced as a span of its own.
+func (api *GraphAPI) do(r Resource, req *http.Request) (*http.Response, error) {
+	client, err := api.Client()
+	if err != nil {
+		return nil, err
+	}
+	api.prepareRequest(req)
+
+	for attempt := 0; ; attempt++ {
+		start := time.Now()
+		attemptReq, span := api.startAttempt(r, req, attempt)
+		resp, err := client.Do(attemptReq)
+		endAttempt(span, resp, err)
+		if err != nil {
+			api.metrics.ObserveRequest(r.Name, req.Method, 0, time.Since(start))
+			return nil, &GraphAPIError{err.Error(), err}
+		}
+		api.metrics.ObserveRequest(r.Name, req.Method, resp.StatusCode, time.Since(start))
+		api.log.Debugf("%s %s: %s (request-id %v, client-request-id %v)",
+			req.Method, req.URL, resp.Status,
+			resp.Header.Get(HeaderRequestID), req.Header.Get(HeaderClientRequestID))
+
+		if !isThrottled(resp.StatusCode) {
+			return resp, nil
+		}
+		api.metrics.IncThrottled(r.Name)
+		if attempt >= api.maxRetries {
+			return resp, nil
+		}
+		if req.Body != nil {
+			if req.GetBody == nil {
+				return resp, nil
+			}
+			if req.Body, err = req.GetBody(); err != nil {
+				return resp, nil
+			}
+		}
+
+		delay := retryDelay(resp, attempt)
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+
+		api.log.Debugf("%s %s was throttled (%s); retrying in %v",
+			req.Method, req.URL, resp.Status, delay)
+		api.metrics.IncRetry(r.Name)
+
+		t := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			t.Stop()
+			return nil, &GraphAPIError{req.Context().Err().Error(), req.Context().Err()}
+		case <-t.C:
+		}
+	}
+}
+
+// send sends a request for resource r to u. If in is not nil, it is
+// encoded as the JSON body of the request; if out is not nil, the JSON
+// body of the response is decoded into it. An error status is returned
+// as a *ResponseError.
+func (api *GraphAPI) send(ctx context.Context, r Resource, method string, u *url.URL, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return &GraphAPIError{fmt.Sprintf("Encoding request: %v", err), err}
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return &GraphAPIError{fmt.Sprintf("Creating request: %v", err), err}
+	}
+	req = req.WithContext(ctx)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := api.do(r, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return &GraphAPIError{fmt.Sprintf("Decoding response: %v", err), err}
+	}
+	return nil
+}
+
+// withoutNulls encodes v as a JSON object without its null properties,
+// which is how entities are sent when they are created.
+func withoutNulls(v interface{}) (json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var props map[string]json.RawMessage
+	if err := json.Unmarshal(data, &props); err != nil {
+		return nil, err
+	}
+	for k, p := range props {
+		if string(p) == "null" {
+			delete(props, k)
+		}
+	}
+	return json.Marshal(props)
+}
@@ -3,37 +3,183 @@ package msgraph
 import (
 	"fmt"
 	"net/url"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/context"
 )
 
-var resources map[string]Resource
+// String returns the path segment of the version, such as "v1.0".
+func (v APIVersion) String() string {
+	switch v {
+	case APIVersionV1:
+		return "v1.0"
+	case APIVersionBeta:
+		return "beta"
+	}
+	return fmt.Sprintf("APIVersion(%d)", int(v))
+}
 
-func init() {
-	resources = make(map[string]Resource, 10)
+func (v APIVersion) valid() bool {
+	return v == APIVersionV1 || v == APIVersionBeta
 }
 
+// WithAPIVersion returns a copy of ctx that causes requests made with it
+// to use version v instead of the default version of their resource.
+func WithAPIVersion(ctx context.Context, v APIVersion) context.Context {
+	return context.WithValue(ctx, apiVersionKey, v)
+}
+
+// apiVersion returns the version requests for r made with ctx use.
+func apiVersion(ctx context.Context, r Resource) APIVersion {
+	if v, ok := ctx.Value(apiVersionKey).(APIVersion); ok {
+		return v
+	}
+	return r.APIVersion
+}
+
+// Route is a path template relative to the root of an API version, such
+// as "users/{id}/memberOf". Each placeholder in braces is replaced by an
+// argument when the route is expanded.
+type Route string
+
+// Expand fills in the placeholders of the route, in order, with the
+// path-escaped args.
+func (rt Route) Expand(args ...string) (string, error) {
+	var b strings.Builder
+	s := string(rt)
+	n := 0
+	for {
+		i := strings.IndexByte(s, '{')
+		if i < 0 {
+			b.WriteString(s)
+			break
+		}
+		j := strings.IndexByte(s[i:], '}')
+		if j < 0 {
+			return "", &GraphAPIError{fmt.Sprintf("Route %q has an unterminated placeholder", rt), nil}
+		}
+		if n >= len(args) {
+			return "", &GraphAPIError{fmt.Sprintf("Route %q needs more than %d arguments", rt, len(args)), nil}
+		}
+		if args[n] == "" {
+			return "", &GraphAPIError{fmt.Sprintf("Route %q: %s must not be empty", rt, s[i:i+j+1]), nil}
+		}
+		b.WriteString(s[:i])
+		b.WriteString(url.PathEscape(args[n]))
+		s = s[i+j+1:]
+		n++
+	}
+	if n != len(args) {
+		return "", &GraphAPIError{fmt.Sprintf("Route %q takes %d arguments, got %d", rt, n, len(args)), nil}
+	}
+	return b.String(), nil
+}
+
+// Resource describes a family of Graph endpoints belonging to one
+// entity type, such as the users collection and everything below it.
 type Resource struct {
-	Name       string
+	// Name identifies the resource in the registry, in metrics and
+	// in traces.
+	Name string
+
+	// APIVersion is the version used when a call does not choose
+	// one with WithAPIVersion.
 	APIVersion APIVersion
-	Resource   string
+
+	// Resource is the path of the resource's collection relative to
+	// the root of an API version, for example "users".
+	Resource string
+
+	// Routes are the resource's named path templates, for example
+	// "memberOf": "users/{id}/memberOf".
+	Routes map[string]Route
+}
+
+// Route returns the named route of r. The empty name refers to the
+// resource's collection.
+func (r Resource) Route(name string) (Route, error) {
+	if name == "" {
+		return Route(r.Resource), nil
+	}
+	rt, ok := r.Routes[name]
+	if !ok {
+		return "", &GraphAPIError{fmt.Sprintf("Resource %v has no route %q", r.Name, name), nil}
+	}
+	return rt, nil
 }
 
-func (api GraphAPI) GetResourceEndpoint(r Resource) *url.URL {
-	var v string
+var (
+	resourcesMu sync.RWMutex
+	resources   = make(map[string]Resource)
+)
 
-	// TODO: Clean up the whole APIVersion thing.
-	switch r.APIVersion {
-	case APIVersionV1:
-		v = "v1.0"
-		break
-	case APIVersionBeta:
-		v = "beta"
+// RegisterResource makes a resource available by name through
+// LookupResource and returns it. It is meant to be called while
+// initializing a package, and panics if the name is already registered
+// or a route is malformed.
+func RegisterResource(r Resource) Resource {
+	resourcesMu.Lock()
+	defer resourcesMu.Unlock()
 
+	if r.Name == "" {
+		panic("msgraph: RegisterResource called without a name")
+	}
+	if _, dup := resources[r.Name]; dup {
+		panic("msgraph: RegisterResource called twice for " + r.Name)
+	}
+	if !r.APIVersion.valid() {
+		panic(fmt.Sprintf("msgraph: resource %v has invalid %v", r.Name, r.APIVersion))
 	}
+	for name, rt := range r.Routes {
+		if strings.Count(string(rt), "{") != strings.Count(string(rt), "}") {
+			panic(fmt.Sprintf("msgraph: route %v of resource %v is malformed: %q", name, r.Name, rt))
+		}
+	}
+
+	resources[r.Name] = r
+	return r
+}
 
-	u, err := url.Parse(fmt.Sprintf("%s/%s/%s", api.config.Endpoint.TokenURL, v, r.Resource))
+// LookupResource returns the registered resource with the given name.
+func LookupResource(name string) (Resource, bool) {
+	resourcesMu.RLock()
+	defer resourcesMu.RUnlock()
+
+	r, ok := resources[name]
+	return r, ok
+}
+
+// endpointURL returns the URL of path below the root of an API version.
+func (api *GraphAPI) endpointURL(v APIVersion, path string) (*url.URL, error) {
+	if !v.valid() {
+		return nil, &GraphAPIError{fmt.Sprintf("Invalid API version %v", v), nil}
+	}
+
+	u, err := url.Parse(fmt.Sprintf("%s/%s/%s", api.config.Endpoint.TokenURL, v, path))
 	if err != nil {
-		api.log.Fatal("Creating endpoint: %v", err)
+		return nil, &GraphAPIError{fmt.Sprintf("Creating endpoint: %v", err), err}
 	}
+	return u, nil
+}
+
+// GetResourceEndpoint returns the URL of the collection of r in the
+// resource's default API version.
+func (api *GraphAPI) GetResourceEndpoint(r Resource) (*url.URL, error) {
+	return api.endpointURL(r.APIVersion, r.Resource)
+}
 
-	return u
+// Endpoint returns the URL of the named route of r, with its
+// placeholders filled in by args. The API version is the one chosen by
+// ctx, if any, or else the resource's default.
+func (api *GraphAPI) Endpoint(ctx context.Context, r Resource, route string, args ...string) (*url.URL, error) {
+	rt, err := r.Route(route)
+	if err != nil {
+		return nil, err
+	}
+	path, err := rt.Expand(args...)
+	if err != nil {
+		return nil, err
+	}
+	return api.endpointURL(apiVersion(ctx, r), path)
 }
@@ -0,0 +1,239 @@
+package msgraph
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Selector knows the properties of an entity type that can be used with
+// $select, and named sets of them.
+type Selector struct {
+	entity string
+	props  map[string]bool
+	lower  map[string]string
+	beta   map[string]bool
+	sets   map[string][]string
+}
+
+// newSelector returns a Selector for the entity type of entity. The
+// selectable properties are those of its JSON tags, except for the
+// navigation properties nav, plus the properties beta, which only exist
+// in the beta API.
+func newSelector(entity interface{}, nav, beta []string, sets map[string][]string) *Selector {
+	t := reflect.TypeOf(entity)
+	s := &Selector{
+		entity: t.Name(),
+		props:  make(map[string]bool),
+		lower:  make(map[string]string),
+		beta:   make(map[string]bool),
+		sets:   sets,
+	}
+	for name := range jsonProperties(t) {
+		s.props[name] = true
+	}
+	for _, name := range nav {
+		delete(s.props, name)
+	}
+	for _, name := range beta {
+		s.props[name] = true
+		s.beta[name] = true
+	}
+	for name := range s.props {
+		s.lower[strings.ToLower(name)] = name
+	}
+	return s
+}
+
+// UserSelector knows the selectable properties of users. Its field sets
+// are "basic", "contact", "onprem" and "licensing".
+var UserSelector = newSelector(User{},
+	[]string{"extensions"},
+	[]string{
+		"cloudRealtimeCommunicationInfo",
+		"deviceKeys",
+		"infoCatalogs",
+		"isLicenseReconciliationNeeded",
+		"isManagementRestricted",
+		"onPremisesSipInfo",
+	},
+	map[string][]string{
+		"basic": {
+			"id", "displayName", "userPrincipalName", "mail", "jobTitle",
+			"department", "accountEnabled", "userType",
+		},
+		"contact": {
+			"id", "displayName", "mail", "mobilePhone", "officeLocation",
+			"streetAddress", "city", "state", "postalCode", "country",
+			"proxyAddresses", "preferredLanguage",
+		},
+		"onprem": {
+			"id", "userPrincipalName", "onPremisesImmutableId",
+			"onPremisesLastSyncDateTime", "onPremisesSecurityIdentifier",
+			"onPremisesSyncEnabled", "onPremisesExtensionAttributes",
+		},
+		"licensing": {
+			"id", "userPrincipalName", "usageLocation", "assignedLicenses",
+			"assignedPlans", "provisionedPlans",
+		},
+	})
+
+// Properties returns the selectable properties, sorted.
+func (s *Selector) Properties() []string {
+	names := make([]string, 0, len(s.props))
+	for name := range s.props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FieldSets returns the names of the field sets, sorted.
+func (s *Selector) FieldSets() []string {
+	names := make([]string, 0, len(s.sets))
+	for name := range s.sets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FieldSet returns the properties of the named field set.
+func (s *Selector) FieldSet(name string) ([]string, bool) {
+	props, ok := s.sets[name]
+	return props, ok
+}
+
+// IsBeta reports whether property only exists in the beta API.
+func (s *Selector) IsBeta(property string) bool {
+	return s.beta[property]
+}
+
+// Resolve turns a list of property names, field set names and
+// comma-separated lists of them into the list of properties to select,
+// without duplicates. Property names are matched without regard to
+// case. Extension properties are passed through, and so are names the
+// Selector does not know, since Graph has more properties than the
+// entity type has fields; those are reported in warnings, with the
+// closest matches. The warnings also name the properties that only
+// exist in the beta API if version is not APIVersionBeta.
+func (s *Selector) Resolve(items []string, version APIVersion) (props, warnings []string) {
+	props, warnings, _ = s.resolve(items, version, false)
+	return props, warnings
+}
+
+// ResolveStrict is like Resolve, but unknown names fail with a
+// *ValidationError that suggests the closest matches.
+func (s *Selector) ResolveStrict(items []string, version APIVersion) (props, warnings []string, err error) {
+	return s.resolve(items, version, true)
+}
+
+func (s *Selector) resolve(items []string, version APIVersion, strict bool) (props, warnings []string, err error) {
+	verr := &ValidationError{}
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if seen[name] {
+			return
+		}
+		seen[name] = true
+		props = append(props, name)
+		if s.beta[name] && version != APIVersionBeta {
+			warnings = append(warnings, fmt.Sprintf("%v is only available in the beta API", name))
+		}
+	}
+
+	for _, item := range items {
+		for _, name := range strings.Split(item, ",") {
+			name = strings.TrimSpace(name)
+			if p, ok := s.lower[strings.ToLower(name)]; ok {
+				// Graph matches property names without regard to case.
+				name = p
+			}
+			switch {
+			case name == "":
+			case s.props[name] || isExtensionProperty(name):
+				add(name)
+			case s.sets[name] != nil:
+				for _, p := range s.sets[name] {
+					add(p)
+				}
+			case strict:
+				verr.add(name, "%v", s.unknown(name))
+			default:
+				warnings = append(warnings, fmt.Sprintf("%v %v", name, s.unknown(name)))
+				add(name)
+			}
+		}
+	}
+	return props, warnings, verr.err()
+}
+
+// unknown describes the unknown property name, with suggestions.
+func (s *Selector) unknown(name string) string {
+	msg := fmt.Sprintf("is not a known property of %v", s.entity)
+	if m := s.closest(name); len(m) > 0 {
+		msg += fmt.Sprintf("; did you mean %v?", strings.Join(m, " or "))
+	}
+	return msg
+}
+
+// closest returns the properties and field sets nearest to name, if
+// any is near enough to be a likely typo.
+func (s *Selector) closest(name string) []string {
+	lower := strings.ToLower(name)
+	best := len(name)/3 + 1
+	var matches []string
+	consider := func(candidate string) {
+		d := editDistance(lower, strings.ToLower(candidate))
+		switch {
+		case d < best:
+			best = d
+			matches = []string{candidate}
+		case d == best:
+			matches = append(matches, candidate)
+		}
+	}
+	for p := range s.props {
+		consider(p)
+	}
+	for set := range s.sets {
+		consider(set)
+	}
+	sort.Strings(matches)
+	if len(matches) > 3 {
+		matches = matches[:3]
+	}
+	return matches
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
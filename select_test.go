@@ -0,0 +1,61 @@
+package msgraph
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSelectorResolve(t *testing.T) {
+	tests := []struct {
+		items    []string
+		version  APIVersion
+		want     []string
+		warnings []string
+	}{
+		// Names are matched without regard to case and not repeated.
+		{[]string{"DisplayName,id", "displayName"}, APIVersionV1, []string{"displayName", "id"}, nil},
+		{[]string{"licensing"}, APIVersionV1, []string{"id", "userPrincipalName", "usageLocation", "assignedLicenses", "assignedPlans", "provisionedPlans"}, nil},
+		{[]string{"extension_abc_employeeNumber"}, APIVersionV1, []string{"extension_abc_employeeNumber"}, nil},
+
+		// Properties of the beta API are selected with a warning.
+		{[]string{"deviceKeys"}, APIVersionV1, []string{"deviceKeys"}, []string{"deviceKeys is only available"}},
+		{[]string{"deviceKeys"}, APIVersionBeta, []string{"deviceKeys"}, nil},
+
+		// Properties User has no field for are passed through to Graph.
+		{[]string{"businessPhones,employeeId", "signInActivity"}, APIVersionV1, []string{"businessPhones", "employeeId", "signInActivity"}, []string{"businessPhones is not", "employeeId is not", "signInActivity is not"}},
+		{[]string{"jobTittle"}, APIVersionV1, []string{"jobTittle"}, []string{"did you mean jobTitle?"}},
+	}
+
+	for _, tt := range tests {
+		props, warnings := UserSelector.Resolve(tt.items, tt.version)
+		if !reflect.DeepEqual(props, tt.want) {
+			t.Errorf("Resolve(%q) = %q, want %q", tt.items, props, tt.want)
+		}
+		if len(warnings) != len(tt.warnings) {
+			t.Errorf("Resolve(%q) warnings = %q, want %q", tt.items, warnings, tt.warnings)
+			continue
+		}
+		for i, w := range warnings {
+			if !strings.Contains(w, tt.warnings[i]) {
+				t.Errorf("Resolve(%q) warning %q does not contain %q", tt.items, w, tt.warnings[i])
+			}
+		}
+	}
+}
+
+func TestSelectorResolveStrict(t *testing.T) {
+	props, _, err := UserSelector.ResolveStrict([]string{"basic"}, APIVersionV1)
+	if err != nil || len(props) == 0 {
+		t.Errorf("ResolveStrict(basic) = %q, %v", props, err)
+	}
+
+	_, _, err = UserSelector.ResolveStrict([]string{"displayName,jobTittle"}, APIVersionV1)
+	verr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("ResolveStrict(jobTittle) error = %v, want a *ValidationError", err)
+	}
+	if !strings.Contains(verr.Error(), "did you mean jobTitle?") {
+		t.Errorf("ResolveStrict(jobTittle) error = %v, want a suggestion", err)
+	}
+}
@@ -0,0 +1,97 @@
+package msgraph
+
+import "strings"
+
+// SKUInfo describes a well-known license SKU.
+type SKUInfo struct {
+	// The unique identifier of the SKU.
+	ID string
+
+	// The SKU part number, for example "ENTERPRISEPACK".
+	PartNumber string
+
+	// The product name, for example "Office 365 E3".
+	Name string
+}
+
+// ServicePlanDetails describes a well-known service plan.
+type ServicePlanDetails struct {
+	// The unique identifier of the service plan.
+	ID string
+
+	// The service plan name, for example "EXCHANGE_S_ENTERPRISE".
+	PlanName string
+
+	// The product name, for example "Exchange Online (Plan 2)".
+	Name string
+}
+
+// skuCatalog lists common SKUs. Microsoft publishes the full list in
+// "Product names and service plan identifiers for licensing".
+var skuCatalog = []SKUInfo{
+	{"18181a46-0d4e-45cd-891e-60aabd171b4e", "STANDARDPACK", "Office 365 E1"},
+	{"6fd2c87f-b296-42f0-b197-1e91e994b900", "ENTERPRISEPACK", "Office 365 E3"},
+	{"c7df2760-2c81-4ef7-b578-5b5392b571df", "ENTERPRISEPREMIUM", "Office 365 E5"},
+	{"189a915c-fe4f-4ffa-bde4-85b9628d07a0", "DEVELOPERPACK", "Office 365 E3 Developer"},
+	{"c42b9cae-ea4f-4ab7-9717-81576235ccac", "DEVELOPERPACK_E5", "Microsoft 365 E5 Developer"},
+	{"05e9a617-0261-4cee-bb44-138d3ef5d965", "SPE_E3", "Microsoft 365 E3"},
+	{"06ebc4ee-1bb5-47dd-8120-11324bc54e06", "SPE_E5", "Microsoft 365 E5"},
+	{"3b555118-da6a-4418-894f-7df1e2096870", "O365_BUSINESS_ESSENTIALS", "Microsoft 365 Business Basic"},
+	{"f245ecc8-75af-4f8e-b61f-27d8114de5f3", "O365_BUSINESS_PREMIUM", "Microsoft 365 Business Standard"},
+	{"cbdc14ab-d96c-4c30-b9f4-6ada7cdc1d46", "SPB", "Microsoft 365 Business Premium"},
+	{"4b9405b0-7788-4568-add1-99614e613b69", "EXCHANGESTANDARD", "Exchange Online (Plan 1)"},
+	{"19ec0d23-8335-4cbd-94ac-6050e30712fa", "EXCHANGEENTERPRISE", "Exchange Online (Plan 2)"},
+	{"efccb6f7-5641-4e0e-bd10-b4976e1bf68e", "EMS", "Enterprise Mobility + Security E3"},
+	{"b05e124f-c7cc-45a0-a6aa-8cf78c946968", "EMSPREMIUM", "Enterprise Mobility + Security E5"},
+	{"078d2b04-f1bd-4111-bbd4-b4b1b354cef4", "AAD_PREMIUM", "Microsoft Entra ID P1"},
+	{"84a661c4-e949-4bd2-a560-ed7766fcaf2b", "AAD_PREMIUM_P2", "Microsoft Entra ID P2"},
+	{"f8a1db68-be16-40ed-86d5-cb42ce701560", "POWER_BI_PRO", "Power BI Pro"},
+	{"a403ebcc-fae0-4ca2-8c8c-7a907fd6c235", "POWER_BI_STANDARD", "Power BI (free)"},
+	{"f30db892-07e9-47e9-837c-80727f46fd3d", "FLOW_FREE", "Microsoft Power Automate Free"},
+	{"c5928f49-12ba-48f7-ada3-0d743a3601d5", "VISIOCLIENT", "Visio Online Plan 2"},
+	{"53818b1b-4a27-454b-8896-0dba576410e6", "PROJECTPROFESSIONAL", "Project Plan 3"},
+}
+
+// servicePlanCatalog lists common service plans.
+var servicePlanCatalog = []ServicePlanDetails{
+	{"9aaf7827-d63c-4b61-89c3-182f06f82e5c", "EXCHANGE_S_STANDARD", "Exchange Online (Plan 1)"},
+	{"efb87545-963c-4e0d-99df-69c6916d9eb0", "EXCHANGE_S_ENTERPRISE", "Exchange Online (Plan 2)"},
+	{"c7699d2e-19aa-44de-8edf-1736da088ca1", "SHAREPOINTSTANDARD", "SharePoint (Plan 1)"},
+	{"5dbe027f-2339-4123-9542-606e4d348a72", "SHAREPOINTENTERPRISE", "SharePoint (Plan 2)"},
+	{"0feaeb32-d00e-4d66-bd5a-43b5b83db82c", "MCOSTANDARD", "Skype for Business Online (Plan 2)"},
+	{"4828c8ec-dc2e-4779-b502-87ac9ce28ab7", "MCOEV", "Microsoft Teams Phone Standard"},
+	{"57ff2da0-773e-42df-b2af-ffb7a2317929", "TEAMS1", "Microsoft Teams"},
+	{"43de0ff5-c92c-492b-9116-175376d08c38", "OFFICESUBSCRIPTION", "Microsoft 365 Apps for enterprise"},
+	{"7547a3fe-08ee-4ccb-b430-5077c5041653", "YAMMER_ENTERPRISE", "Viva Engage (Yammer)"},
+	{"a23b959c-7ce8-4e57-9140-b90eb88a9e97", "SWAY", "Sway"},
+	{"2789c901-c14e-48ab-a76a-be334d9d793a", "FORMS_PLAN_E3", "Microsoft Forms (Plan E3)"},
+	{"76846ad7-7776-4c40-a281-a386362dd1b9", "FLOW_O365_P2", "Power Automate for Office 365"},
+	{"c68f8d98-5534-41c8-bf36-22fa496fa792", "POWERAPPS_O365_P2", "Power Apps for Office 365"},
+	{"41781fb2-bc02-4b7c-bd55-b576c07bb09d", "AAD_PREMIUM", "Microsoft Entra ID P1"},
+	{"eec0eb4f-6444-4f95-aba0-50c24d67f998", "AAD_PREMIUM_P2", "Microsoft Entra ID P2"},
+	{"c1ec4a95-1f05-45b3-a911-aa3fa01094f5", "INTUNE_A", "Microsoft Intune"},
+	{"bea4c11e-220a-4e6d-8eb8-8ea15d019f90", "RMS_S_ENTERPRISE", "Azure Rights Management"},
+	{"70d33638-9c74-4d01-bfd3-562de28bd4ba", "BI_AZURE_P2", "Power BI Pro"},
+}
+
+// LookupSKU finds a SKU in the built-in catalog by its ID or its part
+// number, ignoring case.
+func LookupSKU(idOrPartNumber string) (SKUInfo, bool) {
+	for _, s := range skuCatalog {
+		if strings.EqualFold(s.ID, idOrPartNumber) || strings.EqualFold(s.PartNumber, idOrPartNumber) {
+			return s, true
+		}
+	}
+	return SKUInfo{}, false
+}
+
+// LookupServicePlan finds a service plan in the built-in catalog by its
+// ID or its plan name, ignoring case.
+func LookupServicePlan(idOrName string) (ServicePlanDetails, bool) {
+	for _, p := range servicePlanCatalog {
+		if strings.EqualFold(p.ID, idOrName) || strings.EqualFold(p.PlanName, idOrName) {
+			return p, true
+		}
+	}
+	return ServicePlanDetails{}, false
+}
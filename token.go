@@ -6,7 +6,7 @@ import (
 	"golang.org/x/oauth2"
 )
 
-func (api GraphAPI) GetToken() (*oauth2.Token, error) {
+func (api *GraphAPI) GetToken() (*oauth2.Token, error) {
 	var err error
 
 	if api.token == nil {
@@ -0,0 +1,86 @@
+package msgraph
+
+import (
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/net/context"
+)
+
+// instrumentationName identifies this library to OpenTelemetry.
+const instrumentationName = "github.com/crosse/msgraph"
+
+// Span attribute keys used by this library.
+const (
+	attrResource   = attribute.Key("msgraph.resource")
+	attrAttempt    = attribute.Key("msgraph.retry_attempt")
+	attrRequestID  = attribute.Key("msgraph.request_id")
+	attrClientID   = attribute.Key("msgraph.client_request_id")
+	attrMethod     = attribute.Key("http.method")
+	attrURL        = attribute.Key("http.url")
+	attrStatusCode = attribute.Key("http.status_code")
+)
+
+// SetTracerProvider sets the OpenTelemetry TracerProvider used to create
+// spans for Graph operations and the HTTP requests they send. Passing
+// nil reverts to the global TracerProvider, which does nothing unless
+// the application has configured one.
+func (api *GraphAPI) SetTracerProvider(tp trace.TracerProvider) {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	api.tracer = tp.Tracer(instrumentationName)
+}
+
+// startOperation starts the span that covers a whole GraphAPI operation,
+// such as GetUser, as a child of any span carried by ctx.
+func (api *GraphAPI) startOperation(ctx context.Context, name string, r Resource) (context.Context, trace.Span) {
+	return api.tracer.Start(ctx, name, trace.WithAttributes(attrResource.String(r.Name)))
+}
+
+// endOperation ends an operation span, marking it as failed if err is
+// not nil.
+func endOperation(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// startAttempt starts the span that covers a single HTTP request.
+func (api *GraphAPI) startAttempt(r Resource, req *http.Request, attempt int) (*http.Request, trace.Span) {
+	ctx, span := api.tracer.Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attrResource.String(r.Name),
+			attrMethod.String(req.Method),
+			attrURL.String(req.URL.String()),
+			attrAttempt.Int(attempt),
+			attrClientID.String(req.Header.Get(HeaderClientRequestID)),
+		))
+	return req.WithContext(ctx), span
+}
+
+// endAttempt ends the span of a single HTTP request, recording the
+// outcome of the request.
+func endAttempt(span trace.Span, resp *http.Response, err error) {
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	default:
+		span.SetAttributes(attrStatusCode.Int(resp.StatusCode))
+		if id := resp.Header.Get(HeaderRequestID); id != "" {
+			span.SetAttributes(attrRequestID.String(id))
+		}
+		if resp.StatusCode >= 400 {
+			span.SetStatus(codes.Error, strconv.Itoa(resp.StatusCode))
+		}
+	}
+	span.End()
+}
@@ -0,0 +1,104 @@
+package msgraph
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"golang.org/x/net/context"
+)
+
+func TestOperationSpans(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set(HeaderRequestID, fmt.Sprintf("req-%d", calls))
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"id":"a"}`)
+	}))
+	defer srv.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	api := New("contoso.com")
+	api.client = srv.Client()
+	api.config.Endpoint.TokenURL = srv.URL
+	api.SetTracerProvider(tp)
+
+	if _, err := api.GetUser(context.Background(), "a", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 3 {
+		t.Fatalf("got %d spans, want 3", len(spans))
+	}
+	// Spans are exported as they end: both attempts, then the
+	// operation.
+	op := spans[2]
+	if op.Name != "GetUser" {
+		t.Errorf("operation span is %q, want GetUser", op.Name)
+	}
+	if v := attr(op.Attributes, attrResource); v != "User" {
+		t.Errorf("operation %v = %q, want User", attrResource, v)
+	}
+	if op.Status.Code != codes.Unset {
+		t.Errorf("operation status = %v, want Unset", op.Status.Code)
+	}
+
+	tests := []struct {
+		attempt   string
+		status    string
+		requestID string
+		code      codes.Code
+	}{
+		{"0", "429", "req-1", codes.Error},
+		{"1", "200", "req-2", codes.Unset},
+	}
+	for i, tt := range tests {
+		s := spans[i]
+		if s.Name != "HTTP GET" {
+			t.Errorf("attempt %d: span is %q, want HTTP GET", i, s.Name)
+		}
+		if s.Parent.SpanID() != op.SpanContext.SpanID() {
+			t.Errorf("attempt %d: not a child of the operation span", i)
+		}
+		for _, c := range []struct {
+			key  attribute.Key
+			want string
+		}{
+			{attrResource, "User"},
+			{attrAttempt, tt.attempt},
+			{attrStatusCode, tt.status},
+			{attrRequestID, tt.requestID},
+		} {
+			if got := attr(s.Attributes, c.key); got != c.want {
+				t.Errorf("attempt %d: %v = %q, want %q", i, c.key, got, c.want)
+			}
+		}
+		if s.Status.Code != tt.code {
+			t.Errorf("attempt %d: status = %v, want %v", i, s.Status.Code, tt.code)
+		}
+	}
+}
+
+// attr returns the value of the attribute with the given key as a
+// string, or "" if there is none.
+func attr(attrs []attribute.KeyValue, key attribute.Key) string {
+	for _, kv := range attrs {
+		if kv.Key == key {
+			return kv.Value.Emit()
+		}
+	}
+	return ""
+}
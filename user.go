@@ -3,17 +3,46 @@ package msgraph
 import (
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"net/http"
+	"reflect"
 	"strings"
-	"time"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/crosse/msgraph/edm"
 	"github.com/guregu/null"
+	"golang.org/x/net/context"
 )
 
-func init() {
-	resources["UserV1"] = Resource{"User", APIVersionV1, "users"}
-}
+var userResource = RegisterResource(Resource{
+	Name:       "User",
+	APIVersion: APIVersionV1,
+	Resource:   "users",
+	Routes: map[string]Route{
+		"item":          "users/{id}",
+		"manager":       "users/{id}/manager",
+		"managerRef":    "users/{id}/manager/$ref",
+		"directReports": "users/{id}/directReports",
+
+		"memberOf":           "users/{id}/memberOf",
+		"transitiveMemberOf": "users/{id}/transitiveMemberOf",
+		"checkMemberGroups":  "users/{id}/checkMemberGroups",
+		"checkMemberObjects": "users/{id}/checkMemberObjects",
+
+		"assignLicense": "users/{id}/assignLicense",
+
+		"extensions": "users/{id}/extensions",
+		"extension":  "users/{id}/extensions/{name}",
+
+		"photo":           "users/{id}/photo",
+		"photoValue":      "users/{id}/photo/$value",
+		"sizedPhoto":      "users/{id}/photos/{size}",
+		"sizedPhotoValue": "users/{id}/photos/{size}/$value",
+
+		"revokeSignInSessions": "users/{id}/revokeSignInSessions",
+
+		"appRoleAssignments": "users/{id}/appRoleAssignments",
+	},
+})
 
 type AssignedLicense struct {
 	// A collection of the unique identifiers for plans that have
@@ -25,8 +54,8 @@ type AssignedLicense struct {
 }
 
 type AssignedPlan struct {
-	// The date and tiem at which the plan was assigned, in UTC.
-	AssignedDateTime time.Time `json:"assignedDateTime"`
+	// The date and time at which the plan was assigned, in UTC.
+	AssignedDateTime *edm.DateTimeOffset `json:"assignedDateTime,omitempty"`
 
 	// Whether the plan is enabled. For example, "Enabled".
 	CapabilityStatus string `json:"capabilityStatus"`
@@ -35,7 +64,7 @@ type AssignedPlan struct {
 	Service string `json:"service"`
 
 	// A GUID that identifies the service plan.
-	ServicePlanId string `json:"servicePlanID"`
+	ServicePlanId string `json:"servicePlanId"`
 }
 
 type PasswordProfile struct {
@@ -43,6 +72,11 @@ type PasswordProfile struct {
 	// next login.
 	ForceChangePasswordOnNextSignIn bool `json:"forceChangePasswordNextSignIn"`
 
+	// Indicates whether the user must perform multi-factor
+	// authentication before changing their password on the next
+	// login.
+	ForceChangePasswordNextSignInWithMfa bool `json:"forceChangePasswordNextSignInWithMfa,omitempty"`
+
 	// The password for the user. This property is required when a
 	// user is created. It can be updated, but the user will be
 	// required to change the password on the next login. The
@@ -57,20 +91,12 @@ type ProvisionedPlan struct {
 	CapabilityStatus string `json:"capabilityStatus"`
 
 	// The provisioning status of this plan. For example, "Success".
-	ProvisioningStatus string `json:provisioningStatus"`
+	ProvisioningStatus string `json:"provisioningStatus"`
 
 	// The name of the service; for example, "AccessControlS2S".
 	Service string `json:"service"`
 }
 
-type ProxyAddress struct {
-	// The address' protocol.
-	Protocol string
-
-	// The address.
-	Address string
-}
-
 type User struct {
 	// A freeform text entry field for the user to describe
 	// themselves.
@@ -88,7 +114,7 @@ type User struct {
 	AssignedPlans []*AssignedPlan `json:"assignedPlans,omitempty"`
 
 	// The birthday of the user, in UTC.
-	Birthday null.Time `json:"birthday,omitempty"`
+	Birthday *edm.DateTimeOffset `json:"birthday,omitempty"`
 
 	// The city in which the user is located.
 	City string `json:"city,omitempty"`
@@ -96,6 +122,10 @@ type User struct {
 	// The country or regiion in which the user is located.
 	Country string `json:"country,omitempty"`
 
+	// The date and time at which the user was deleted, in UTC. Only
+	// set for users in the deleted items container. Read-only.
+	DeletedDateTime *edm.DateTimeOffset `json:"deletedDateTime,omitempty"`
+
 	// The name for the department in which the user works.
 	Department string `json:"department,omitempty"`
 
@@ -109,7 +139,7 @@ type User struct {
 	GivenName string `json:"givenName,omitempty"`
 
 	// The hire date of the user, in UTC.
-	HireDate null.Time `json:"hireDate,omitempty"`
+	HireDate *edm.DateTimeOffset `json:"hireDate,omitempty"`
 
 	// The unique identifier for the user. Not nullable. Read-only.
 	ID string `json:"id,omitempty"`
@@ -146,7 +176,12 @@ type User struct {
 
 	// Indicates the last time at which the object was synced with
 	// the on-premises directory, in UTC. Read-only.
-	OnPremisesLastSyncDateTime null.Time `json:"onPremisesLastSyncDateTime,omitempty"`
+	OnPremisesLastSyncDateTime *edm.DateTimeOffset `json:"onPremisesLastSyncDateTime,omitempty"`
+
+	// The fifteen customizable extension attributes of the user.
+	// For users that are synchronized from an on-premises directory,
+	// they can only be changed on-premises.
+	OnPremisesExtensionAttributes *OnPremisesExtensionAttributes `json:"onPremisesExtensionAttributes,omitempty"`
 
 	// Contains the on-premises security identifier (SID) for the
 	// user that was synchronized from on-premises to the cloud.
@@ -197,9 +232,9 @@ type User struct {
 	// nullable.
 	ProvisionedPlans []*ProvisionedPlan `json:"provisionedPlans,omitempty"`
 
-	// An array of email addresses associated with the user.
-	// TODO: change this to []ProxyAddress.
-	ProxyAddresses []string `json:"proxyAddresses,omitempty"`
+	// The email and other addresses associated with the user, such
+	// as "SMTP:alice@example.com" for the primary SMTP address.
+	ProxyAddresses ProxyAddresses `json:"proxyAddresses,omitempty"`
 
 	// A list for the user to enumerate their responsibilities.
 	Responsibilities []string `json:"responsibilities,omitempty"`
@@ -239,37 +274,510 @@ type User struct {
 	// A string value that can be used to classify user types in
 	// your directory, such as "Member" and "Guest".
 	UserType string `json:"userType,omitempty"`
+
+	// The open extensions of the user. Only returned when expanded
+	// with $expand=extensions.
+	Extensions []OpenExtension `json:"extensions,omitempty"`
+
+	// The directory extension and schema extension properties of the
+	// user, keyed by their names, for example
+	// "extension_b7d8e648520f41d3b9c0fdeb91768a0a_employeeNumber".
+	// They are only returned when selected by name.
+	ExtensionProperties map[string]json.RawMessage `json:"-"`
+
+	// AdditionalData holds the properties and annotations returned by
+	// Graph that User has no field for, such as beta-only properties
+	// or @odata.type, keyed by their names.
+	AdditionalData map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler. Extension properties and
+// additional data are encoded alongside the built-in properties.
+func (u User) MarshalJSON() ([]byte, error) {
+	type user User
+	return marshalWithAdditional(user(u), u.ExtensionProperties, u.AdditionalData)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (u *User) UnmarshalJSON(data []byte) error {
+	type user User
+	if err := json.Unmarshal(data, (*user)(u)); err != nil {
+		return err
+	}
+	unknown, err := unknownProperties(data, reflect.TypeOf(*u))
+	if err != nil {
+		return err
+	}
+
+	u.ExtensionProperties, u.AdditionalData = nil, nil
+	for k, v := range unknown {
+		if isExtensionProperty(k) {
+			setRaw(&u.ExtensionProperties, k, v)
+		} else {
+			setRaw(&u.AdditionalData, k, v)
+		}
+	}
+	return nil
+}
+
+func (u *User) extensionProperties() map[string]json.RawMessage {
+	return u.ExtensionProperties
+}
+
+// Extension decodes the extension property name into v, which is
+// usually a *string. It reports whether the property is set.
+func (u *User) Extension(name string, v interface{}) (bool, error) {
+	return getExtension(u.ExtensionProperties, name, v)
+}
+
+// SetExtension encodes v as the extension property name.
+func (u *User) SetExtension(name string, v interface{}) error {
+	return setExtension(&u.ExtensionProperties, name, v)
 }
 
 // GetUser retrieves the properties and relationships of a user object.
 // The id parameter can be either a user ID or user principal name.
-func (api *GraphAPI) GetUser(id string, properties []string) (user User, err error) {
+// properties may name field sets of UserSelector; properties it does
+// not know are requested anyway, with a warning, and are returned in
+// AdditionalData.
+func (api *GraphAPI) GetUser(ctx context.Context, id string, properties []string) (user User, err error) {
+	ctx, span := api.startOperation(ctx, "GetUser", userResource)
+	defer func() { endOperation(span, err) }()
+
 	log.WithFields(log.Fields{
 		"user": id,
 	}).Info("Getting user from Graph API")
 
-	endpoint := fmt.Sprintf("%s/%s", api.GetResourceEndpoint(resources["UserV1"]), id)
+	properties, warnings := UserSelector.Resolve(properties, apiVersion(ctx, userResource))
+	for _, w := range warnings {
+		log.Warn(w)
+	}
+
+	endpoint, err := api.Endpoint(ctx, userResource, "item", id)
+	if err != nil {
+		return
+	}
 	if len(properties) > 0 {
-		endpoint = fmt.Sprintf("%s?$select=%s", endpoint, strings.Join(properties, ","))
+		endpoint.RawQuery = "$select=" + strings.Join(properties, ",")
 	}
 
-	client, err := api.Client()
+	req, err := http.NewRequest("GET", endpoint.String(), nil)
 	if err != nil {
 		return
 	}
+	req = req.WithContext(ctx)
 
-	resp, err := client.Get(endpoint)
+	resp, err := api.do(userResource, req)
+	if err != nil {
+		return
+	}
 	log.Debugf("Response: %v", resp)
 
 	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
+	if err = checkResponse(resp); err != nil {
+		return
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return
+	}
+
+	return
+}
+
+// UserIterator iterates over the users returned by ListUsers.
+type UserIterator struct {
+	c    *Collection
+	user User
+}
+
+// Next advances the iterator to the next user, which can then be read
+// with User. It returns false when there are no more users or an error
+// occurred; Err tells the two apart.
+func (it *UserIterator) Next() bool {
+	if !it.c.Next() {
+		return false
+	}
+	it.user = User{}
+	if err := it.c.Decode(&it.user); err != nil {
+		it.c.Close()
+		return false
+	}
+	return true
+}
+
+// User returns the current user.
+func (it *UserIterator) User() User {
+	return it.user
+}
+
+// Count returns the total number of matching users, if Query.Count was
+// set.
+func (it *UserIterator) Count() (int64, bool) {
+	return it.c.Count()
+}
+
+// Err returns the first error encountered while iterating.
+func (it *UserIterator) Err() error {
+	return it.c.Err()
+}
+
+// Close stops the iteration early.
+func (it *UserIterator) Close() error {
+	return it.c.Close()
+}
+
+// ListUsers returns an iterator over the users in the directory that
+// match q, which may be nil. Pages are requested as the iterator
+// advances.
+func (api *GraphAPI) ListUsers(ctx context.Context, q *Query) *UserIterator {
+	log.WithFields(log.Fields{
+		"filter": q.values().Get("$filter"),
+	}).Info("Listing users from Graph API")
+
+	endpoint, err := api.Endpoint(ctx, userResource, "")
 	if err != nil {
+		return &UserIterator{c: errCollection(err)}
+	}
+	ctx = q.apply(ctx, endpoint)
+
+	return &UserIterator{c: api.newCollection(ctx, "ListUsers", userResource, endpoint.String())}
+}
+
+// mailNicknameInvalid lists the characters that cannot appear in a
+// mail nickname.
+const mailNicknameInvalid = "@()\\[]\";:<>, "
+
+// upnAliasValid reports whether c may appear in the alias part of a
+// user principal name.
+func upnAliasValid(c rune) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		strings.ContainsRune("'.-_!#^~", c)
+}
+
+// validateCreate checks that u has the properties Graph requires when a
+// user is created.
+func (u *User) validateCreate() error {
+	e := &ValidationError{}
+
+	if !u.AccountEnabled.Valid {
+		e.add("accountEnabled", "is required")
+	}
+	if strings.TrimSpace(u.DisplayName) == "" {
+		e.add("displayName", "is required")
+	}
+
+	switch {
+	case u.MailNickname == "":
+		e.add("mailNickname", "is required")
+	case len(u.MailNickname) > 64:
+		e.add("mailNickname", "must be at most 64 characters long")
+	default:
+		for _, c := range u.MailNickname {
+			if c > 127 || strings.ContainsRune(mailNicknameInvalid, c) {
+				e.add("mailNickname", "must not contain %q", c)
+				break
+			}
+		}
+	}
+
+	if u.UserPrincipalName == "" {
+		e.add("userPrincipalName", "is required")
+	} else if err := validateUPN(u.UserPrincipalName); err != "" {
+		e.add("userPrincipalName", "%s", err)
+	}
+
+	if u.PasswordProfile == nil || u.PasswordProfile.Password == "" {
+		e.add("passwordProfile", "a password is required")
+	} else if err := CheckPassword(u.PasswordProfile.Password, u.PasswordPolicies); err != nil {
+		e.Errors = append(e.Errors, err.(*FieldError))
+	}
+
+	return e.err()
+}
+
+// validateUPN checks that upn has the form alias@domain, returning a
+// description of the problem if it does not.
+func validateUPN(upn string) string {
+	i := strings.LastIndexByte(upn, '@')
+	if i < 0 || strings.Count(upn, "@") != 1 {
+		return "must have the form alias@domain"
+	}
+	alias, domain := upn[:i], upn[i+1:]
+	switch {
+	case alias == "":
+		return "the alias must not be empty"
+	case len(alias) > 64:
+		return "the alias must be at most 64 characters long"
+	case strings.HasPrefix(alias, ".") || strings.HasSuffix(alias, "."):
+		return "the alias must not start or end with a period"
+	case !strings.Contains(domain, ".") || strings.HasPrefix(domain, ".") || strings.HasSuffix(domain, "."):
+		return fmt.Sprintf("%q is not a valid domain", domain)
+	}
+	for _, c := range alias {
+		if !upnAliasValid(c) {
+			return fmt.Sprintf("the alias must not contain %q", c)
+		}
+	}
+	return ""
+}
+
+// CreateUser creates a user in the directory and returns it as created
+// by Graph. The user is validated before anything is sent; a
+// *ValidationError lists the properties that are missing or invalid.
+func (api *GraphAPI) CreateUser(ctx context.Context, user User) (created User, err error) {
+	ctx, span := api.startOperation(ctx, "CreateUser", userResource)
+	defer func() { endOperation(span, err) }()
+
+	if err = user.validateCreate(); err != nil {
 		return
 	}
 
-	if err = json.Unmarshal(body, &user); err != nil {
+	log.WithFields(log.Fields{
+		"user": user.UserPrincipalName,
+	}).Info("Creating user in Graph API")
+
+	endpoint, err := api.Endpoint(ctx, userResource, "")
+	if err != nil {
+		return
+	}
+	body, err := withoutNulls(user)
+	if err != nil {
 		return
 	}
 
+	err = api.send(ctx, userResource, "POST", endpoint, body, &created)
 	return
 }
+
+// NewUserPatch returns an empty Patch for a user.
+func NewUserPatch() *Patch {
+	return NewPatch(User{})
+}
+
+// UpdateUser applies patch to the user with the given ID or user
+// principal name. Only the properties in the patch are sent. A patch
+// can be built with NewUserPatch or computed with Diff.
+func (api *GraphAPI) UpdateUser(ctx context.Context, id string, patch *Patch) (err error) {
+	ctx, span := api.startOperation(ctx, "UpdateUser", userResource)
+	defer func() { endOperation(span, err) }()
+
+	if patch.Len() == 0 {
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"user":       id,
+		"properties": patch.Properties(),
+	}).Info("Updating user in Graph API")
+
+	endpoint, err := api.Endpoint(ctx, userResource, "item", id)
+	if err != nil {
+		return
+	}
+	return api.send(ctx, userResource, "PATCH", endpoint, patch, nil)
+}
+
+// DeleteUser deletes the user with the given ID or user principal name.
+// The user is moved to the deleted items container, from which it can
+// be restored with RestoreDeletedUser for 30 days.
+func (api *GraphAPI) DeleteUser(ctx context.Context, id string) (err error) {
+	ctx, span := api.startOperation(ctx, "DeleteUser", userResource)
+	defer func() { endOperation(span, err) }()
+
+	log.WithFields(log.Fields{
+		"user": id,
+	}).Info("Deleting user from Graph API")
+
+	endpoint, err := api.Endpoint(ctx, userResource, "item", id)
+	if err != nil {
+		return
+	}
+	return api.send(ctx, userResource, "DELETE", endpoint, nil, nil)
+}
+
+// ListDeletedUsers returns an iterator over the users in the deleted
+// items container that match q, which may be nil.
+func (api *GraphAPI) ListDeletedUsers(ctx context.Context, q *Query) *UserIterator {
+	endpoint, err := api.Endpoint(ctx, deletedItemResource, "users")
+	if err != nil {
+		return &UserIterator{c: errCollection(err)}
+	}
+	ctx = q.apply(ctx, endpoint)
+
+	return &UserIterator{c: api.newCollection(ctx, "ListDeletedUsers", deletedItemResource, endpoint.String())}
+}
+
+// RestoreDeletedUser restores the deleted user with the given ID and
+// returns it.
+func (api *GraphAPI) RestoreDeletedUser(ctx context.Context, id string) (user User, err error) {
+	ctx, span := api.startOperation(ctx, "RestoreDeletedUser", deletedItemResource)
+	defer func() { endOperation(span, err) }()
+
+	log.WithFields(log.Fields{
+		"user": id,
+	}).Info("Restoring deleted user in Graph API")
+
+	endpoint, err := api.Endpoint(ctx, deletedItemResource, "restore", id)
+	if err != nil {
+		return
+	}
+	err = api.send(ctx, deletedItemResource, "POST", endpoint, nil, &user)
+	return
+}
+
+// PurgeDeletedUser permanently deletes the deleted user with the given
+// ID. A purged user cannot be restored.
+func (api *GraphAPI) PurgeDeletedUser(ctx context.Context, id string) (err error) {
+	ctx, span := api.startOperation(ctx, "PurgeDeletedUser", deletedItemResource)
+	defer func() { endOperation(span, err) }()
+
+	log.WithFields(log.Fields{
+		"user": id,
+	}).Info("Purging deleted user from Graph API")
+
+	endpoint, err := api.Endpoint(ctx, deletedItemResource, "item", id)
+	if err != nil {
+		return
+	}
+	return api.send(ctx, deletedItemResource, "DELETE", endpoint, nil, nil)
+}
+
+// GetManager retrieves the manager of the user with the given ID or
+// user principal name. If the user has no manager, the error satisfies
+// IsNotFound.
+func (api *GraphAPI) GetManager(ctx context.Context, id string, properties []string) (manager User, err error) {
+	ctx, span := api.startOperation(ctx, "GetManager", userResource)
+	defer func() { endOperation(span, err) }()
+
+	endpoint, err := api.Endpoint(ctx, userResource, "manager", id)
+	if err != nil {
+		return
+	}
+	ctx = (&Query{Select: properties}).apply(ctx, endpoint)
+
+	err = api.send(ctx, userResource, "GET", endpoint, nil, &manager)
+	return
+}
+
+// SetManager makes the user with ID managerID the manager of the user
+// with the given ID or user principal name.
+func (api *GraphAPI) SetManager(ctx context.Context, id, managerID string) (err error) {
+	ctx, span := api.startOperation(ctx, "SetManager", userResource)
+	defer func() { endOperation(span, err) }()
+
+	log.WithFields(log.Fields{
+		"user":    id,
+		"manager": managerID,
+	}).Info("Setting manager in Graph API")
+
+	endpoint, err := api.Endpoint(ctx, userResource, "managerRef", id)
+	if err != nil {
+		return
+	}
+	ref, err := api.Endpoint(ctx, userResource, "item", managerID)
+	if err != nil {
+		return
+	}
+
+	body := map[string]string{"@odata.id": ref.String()}
+	return api.send(ctx, userResource, "PUT", endpoint, body, nil)
+}
+
+// RemoveManager removes the manager of the user with the given ID or
+// user principal name.
+func (api *GraphAPI) RemoveManager(ctx context.Context, id string) (err error) {
+	ctx, span := api.startOperation(ctx, "RemoveManager", userResource)
+	defer func() { endOperation(span, err) }()
+
+	log.WithFields(log.Fields{
+		"user": id,
+	}).Info("Removing manager in Graph API")
+
+	endpoint, err := api.Endpoint(ctx, userResource, "managerRef", id)
+	if err != nil {
+		return
+	}
+	return api.send(ctx, userResource, "DELETE", endpoint, nil, nil)
+}
+
+// ListDirectReports returns an iterator over the direct reports of the
+// user with the given ID or user principal name. q may be nil.
+func (api *GraphAPI) ListDirectReports(ctx context.Context, id string, q *Query) *UserIterator {
+	endpoint, err := api.Endpoint(ctx, userResource, "directReports", id)
+	if err != nil {
+		return &UserIterator{c: errCollection(err)}
+	}
+	ctx = q.apply(ctx, endpoint)
+
+	return &UserIterator{c: api.newCollection(ctx, "ListDirectReports", userResource, endpoint.String())}
+}
+
+// ListMemberOf returns an iterator over the groups, directory roles and
+// administrative units the user with the given ID or user principal
+// name is a direct member of. q may be nil.
+func (api *GraphAPI) ListMemberOf(ctx context.Context, id string, q *Query) *DirectoryObjectIterator {
+	return api.listUserObjects(ctx, "ListMemberOf", "memberOf", id, q)
+}
+
+// ListTransitiveMemberOf is like ListMemberOf, but also returns the
+// objects the user is a member of through nested groups.
+func (api *GraphAPI) ListTransitiveMemberOf(ctx context.Context, id string, q *Query) *DirectoryObjectIterator {
+	return api.listUserObjects(ctx, "ListTransitiveMemberOf", "transitiveMemberOf", id, q)
+}
+
+func (api *GraphAPI) listUserObjects(ctx context.Context, operation, route, id string, q *Query) *DirectoryObjectIterator {
+	endpoint, err := api.Endpoint(ctx, userResource, route, id)
+	if err != nil {
+		return &DirectoryObjectIterator{c: errCollection(err)}
+	}
+	ctx = q.apply(ctx, endpoint)
+
+	return &DirectoryObjectIterator{c: api.newCollection(ctx, operation, userResource, endpoint.String())}
+}
+
+// checkMemberBatch is the number of IDs Graph accepts in a single
+// checkMemberGroups or checkMemberObjects request.
+const checkMemberBatch = 20
+
+// CheckMemberGroups returns those of groupIDs that the user with the
+// given ID or user principal name is a member of, directly or through
+// nested groups. It is much faster than listing the user's
+// memberships.
+func (api *GraphAPI) CheckMemberGroups(ctx context.Context, id string, groupIDs []string) ([]string, error) {
+	return api.checkMember(ctx, "CheckMemberGroups", "checkMemberGroups", "groupIds", id, groupIDs)
+}
+
+// CheckMemberObjects is like CheckMemberGroups, but also checks
+// membership of directory roles and administrative units.
+func (api *GraphAPI) CheckMemberObjects(ctx context.Context, id string, objectIDs []string) ([]string, error) {
+	return api.checkMember(ctx, "CheckMemberObjects", "checkMemberObjects", "ids", id, objectIDs)
+}
+
+func (api *GraphAPI) checkMember(ctx context.Context, operation, route, param, id string, ids []string) (member []string, err error) {
+	ctx, span := api.startOperation(ctx, operation, userResource)
+	defer func() { endOperation(span, err) }()
+
+	endpoint, err := api.Endpoint(ctx, userResource, route, id)
+	if err != nil {
+		return nil, err
+	}
+
+	member = []string{}
+	for len(ids) > 0 {
+		n := len(ids)
+		if n > checkMemberBatch {
+			n = checkMemberBatch
+		}
+
+		var result struct {
+			Value []string `json:"value"`
+		}
+		body := map[string][]string{param: ids[:n]}
+		if err = api.send(ctx, userResource, "POST", endpoint, body, &result); err != nil {
+			return nil, err
+		}
+		member = append(member, result.Value...)
+		ids = ids[n:]
+	}
+	return member, nil
+}